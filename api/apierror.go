@@ -0,0 +1,150 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// ConstraintViolation is a violated constraint reported in the error payload of an API, e.g. an invalid field of a
+// settings object.
+type ConstraintViolation struct {
+	// Path is the path of the offending field, e.g. "rules[0].name".
+	Path string `json:"path"`
+	// Message describes the violation.
+	Message string `json:"message"`
+	// ParameterLocation is the part of the request containing the offending field, e.g. "PAYLOAD_BODY" or "QUERY".
+	ParameterLocation string `json:"parameterLocation,omitempty"`
+	// Location optionally describes the position of the offending field, e.g. a line and column of the payload.
+	Location string `json:"location,omitempty"`
+}
+
+// errorPayload is the standard error payload of Dynatrace APIs, which is returned either as the "error" field of the
+// response payload, or, for APIs handling several objects at once, as the "error" field of each item.
+type errorPayload struct {
+	Code                 json.RawMessage       `json:"code"`
+	Message              string                `json:"message"`
+	ConstraintViolations []ConstraintViolation `json:"constraintViolations"`
+	// Details are the API specific details of platform APIs, which may contain constraint violations as well.
+	Details      json.RawMessage `json:"details"`
+	ErrorDetails json.RawMessage `json:"errorDetails"`
+}
+
+// errorPayloads parses the error payloads contained in the Body. The Body is parsed on each call, so that APIErrors
+// stay cheap to create.
+func (r APIError) errorPayloads() []errorPayload {
+	data := bytes.TrimSpace(r.Body)
+	if len(data) == 0 {
+		return nil
+	}
+
+	if data[0] == '[' {
+		var items []struct {
+			Error *errorPayload `json:"error"`
+		}
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil
+		}
+		var payloads []errorPayload
+		for _, item := range items {
+			if item.Error != nil {
+				payloads = append(payloads, *item.Error)
+			}
+		}
+		return payloads
+	}
+
+	var envelope struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil
+	}
+
+	// some APIs return the payload without the enclosing "error" field
+	if len(envelope.Error) > 0 && envelope.Error[0] == '{' {
+		data = envelope.Error
+	}
+	var payload errorPayload
+	if err := json.Unmarshal(data, &payload); err != nil || (payload.Message == "" && len(payload.Code) == 0) {
+		return nil
+	}
+	return []errorPayload{payload}
+}
+
+// Code returns the "error.code" of the error payload, or an empty string if there is none.
+func (r APIError) Code() string {
+	for _, p := range r.errorPayloads() {
+		if len(p.Code) == 0 {
+			continue
+		}
+		var code string
+		if err := json.Unmarshal(p.Code, &code); err == nil {
+			return code
+		}
+		return string(p.Code)
+	}
+	return ""
+}
+
+// Message returns the "error.message" of the error payload, or an empty string if there is none.
+// If the payload contains several errors, their messages are joined.
+func (r APIError) Message() string {
+	var messages []string
+	for _, p := range r.errorPayloads() {
+		if p.Message != "" {
+			messages = append(messages, p.Message)
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ConstraintViolations returns all constraint violations of the error payload, including those contained in the
+// details of platform APIs.
+func (r APIError) ConstraintViolations() []ConstraintViolation {
+	var violations []ConstraintViolation
+	for _, p := range r.errorPayloads() {
+		violations = append(violations, p.ConstraintViolations...)
+
+		var details struct {
+			ConstraintViolations []ConstraintViolation `json:"constraintViolations"`
+		}
+		if isPresent(p.Details) && json.Unmarshal(p.Details, &details) == nil {
+			violations = append(violations, details.ConstraintViolations...)
+		}
+	}
+	return violations
+}
+
+// ErrorDetails returns the API specific "error.details" or "error.errorDetails" of platform APIs, e.g. containing an
+// error code or the missing scopes of a token, or nil if there are none.
+func (r APIError) ErrorDetails() json.RawMessage {
+	for _, p := range r.errorPayloads() {
+		if isPresent(p.Details) {
+			return p.Details
+		}
+		if isPresent(p.ErrorDetails) {
+			return p.ErrorDetails
+		}
+	}
+	return nil
+}
+
+// isPresent returns whether the given raw JSON field is present and not null.
+func isPresent(field json.RawMessage) bool {
+	return len(field) > 0 && string(field) != "null"
+}
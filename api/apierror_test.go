@@ -0,0 +1,119 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+)
+
+func TestAPIError_ErrorPayload(t *testing.T) {
+	t.Run("classic API error", func(t *testing.T) {
+		err := api.APIError{StatusCode: http.StatusBadRequest, Body: []byte(`{"error": {
+			"code": 400,
+			"message": "Constraints violated.",
+			"constraintViolations": [{"path": "rules[0].name", "message": "must not be empty", "parameterLocation": "PAYLOAD_BODY", "location": null}]
+		}}`)}
+
+		assert.Equal(t, "400", err.Code())
+		assert.Equal(t, "Constraints violated.", err.Message())
+		assert.Equal(t, []api.ConstraintViolation{{Path: "rules[0].name", Message: "must not be empty", ParameterLocation: "PAYLOAD_BODY"}}, err.ConstraintViolations())
+		assert.Nil(t, err.ErrorDetails())
+	})
+
+	t.Run("platform API error with details", func(t *testing.T) {
+		err := api.APIError{StatusCode: http.StatusBadRequest, Body: []byte(`{"error": {
+			"code": 400,
+			"message": "Invalid workflow.",
+			"details": {"errorCode": "WORKFLOW_INVALID", "constraintViolations": [{"path": "tasks", "message": "is required"}]}
+		}}`)}
+
+		assert.Equal(t, "Invalid workflow.", err.Message())
+		assert.Equal(t, []api.ConstraintViolation{{Path: "tasks", Message: "is required"}}, err.ConstraintViolations())
+		assert.JSONEq(t, `{"errorCode": "WORKFLOW_INVALID", "constraintViolations": [{"path": "tasks", "message": "is required"}]}`, string(err.ErrorDetails()))
+	})
+
+	t.Run("platform API error with error details", func(t *testing.T) {
+		err := api.APIError{Body: []byte(`{"error": {"code": "BAD_REQUEST", "message": "bad", "errorDetails": [{"errorMessage": "detail"}]}}`)}
+
+		assert.Equal(t, "BAD_REQUEST", err.Code())
+		assert.JSONEq(t, `[{"errorMessage": "detail"}]`, string(err.ErrorDetails()))
+	})
+
+	t.Run("errors of several objects", func(t *testing.T) {
+		err := api.APIError{Body: []byte(`[
+			{"code": 200, "objectId": "a"},
+			{"code": 400, "error": {"code": 400, "message": "first", "constraintViolations": [{"path": "a", "message": "x"}]}},
+			{"code": 400, "error": {"code": 400, "message": "second", "constraintViolations": [{"path": "b", "message": "y"}]}}
+		]`)}
+
+		assert.Equal(t, "first; second", err.Message())
+		assert.Len(t, err.ConstraintViolations(), 2)
+	})
+
+	t.Run("payload without error field", func(t *testing.T) {
+		err := api.APIError{Body: []byte(`{"code": 404, "message": "Not found"}`)}
+		assert.Equal(t, "404", err.Code())
+		assert.Equal(t, "Not found", err.Message())
+	})
+
+	t.Run("unknown payloads", func(t *testing.T) {
+		for _, body := range []string{``, `not JSON`, `{"error":"not found"}`, `{"foo":"bar"}`, `[1, 2]`} {
+			err := api.APIError{Body: []byte(body)}
+			assert.Empty(t, err.Code(), body)
+			assert.Empty(t, err.Message(), body)
+			assert.Empty(t, err.ConstraintViolations(), body)
+			assert.Nil(t, err.ErrorDetails(), body)
+		}
+	})
+}
+
+func TestNewAPIErrorFromResponse_Headers(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": {"5"}, "X-Request-Id": {"request-1"}},
+		Body:       io.NopCloser(strings.NewReader(`{"error":{"code":429,"message":"Too many requests"}}`)),
+	}
+
+	err := api.NewAPIErrorFromResponse(resp)
+
+	var apiErr api.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 5*time.Second, apiErr.RetryAfter)
+	assert.Equal(t, "request-1", apiErr.RequestID)
+	assert.Equal(t, resp.Header, apiErr.Header)
+	assert.Equal(t, "Too many requests", apiErr.Message())
+
+	t.Run("rate-limit headers of other errors are no requested delay", func(t *testing.T) {
+		reset := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+		resp := &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{"X-Ratelimit-Reset": {reset}}}
+		assert.Zero(t, api.NewAPIErrorFromResponseAndBody(resp, nil).RetryAfter)
+
+		resp.StatusCode = http.StatusServiceUnavailable
+		assert.Positive(t, api.NewAPIErrorFromResponseAndBody(resp, nil).RetryAfter)
+
+		resp = &http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{"Retry-After": {"5"}}}
+		assert.Equal(t, 5*time.Second, api.NewAPIErrorFromResponseAndBody(resp, nil).RetryAfter)
+	})
+}
@@ -14,7 +14,74 @@
 
 package api
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+// Sentinel errors classifying the errors returned by the API clients, to be checked using errors.Is, e.g.
+//
+//	if errors.Is(err, api.ErrNotFound) { ... }
+//
+// An APIError matches the sentinel of its StatusCode, see APIError.Is, and client-side validation errors match
+// ErrValidation. As the clients wrap these errors, the context of the failed operation, like the resource and its ID,
+// is kept in the error message, and the underlying error can still be retrieved using errors.As.
+var (
+	// ErrNotFound is matched by 404 Not Found responses.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is matched by 409 Conflict and 412 Precondition Failed responses, e.g. of concurrent updates.
+	ErrConflict = errors.New("conflict")
+	// ErrUnauthorized is matched by 401 Unauthorized responses.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is matched by 403 Forbidden responses, e.g. if a token is missing a scope.
+	ErrForbidden = errors.New("forbidden")
+	// ErrRateLimited is matched by 429 Too Many Requests responses, which are returned once retries are exhausted.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrValidation is matched by 400 Bad Request and 422 Unprocessable Entity responses, as well as by client-side
+	// validation errors like ValidationError.
+	ErrValidation = errors.New("validation failed")
+	// ErrServer is matched by 5xx responses.
+	ErrServer = errors.New("server error")
+)
+
+// sentinelForStatusCode returns the sentinel error matched by responses with the given status code, or nil if there
+// is none.
+func sentinelForStatusCode(statusCode int) error {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusConflict, statusCode == http.StatusPreconditionFailed:
+		return ErrConflict
+	case statusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case statusCode == http.StatusForbidden:
+		return ErrForbidden
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode == http.StatusBadRequest, statusCode == http.StatusUnprocessableEntity:
+		return ErrValidation
+	case statusCode >= 500 && statusCode <= 599:
+		return ErrServer
+	default:
+		return nil
+	}
+}
+
+// NewValidationSentinel creates a sentinel error for a client-side validation failure with the given message, which
+// matches ErrValidation as well.
+func NewValidationSentinel(message string) error {
+	return validationSentinel(message)
+}
+
+type validationSentinel string
+
+func (e validationSentinel) Error() string { return string(e) }
+
+func (e validationSentinel) Is(target error) bool { return target == ErrValidation }
 
 // ClientError represents a custom error type used in the client package to wrap errors returned by the rest.Client.
 // It provides additional context such as the operation, resource, and reason for the error.
@@ -38,12 +105,28 @@ type ClientError struct {
 	Reason string
 }
 
+// Error returns the error message, with secrets in the message of the wrapped error redacted using the Redactor of
+// the rest.Client whose request failed, or redact.Default() if it is unknown.
 func (e ClientError) Error() string {
+	redactor := redactorOf(e.Wrapped)
 	if e.Identifier != "" {
-		return fmt.Sprintf("failed to %s in resource %s with id %s: %v", e.Operation, e.Resource, e.Identifier, e.Wrapped)
+		return redactor.String(fmt.Sprintf("failed to %s in resource %s with id %s: %v", e.Operation, e.Resource, e.Identifier, e.Wrapped))
 	}
 
-	return fmt.Sprintf("failed to %s in resource %s: %v", e.Operation, e.Resource, e.Wrapped)
+	return redactor.String(fmt.Sprintf("failed to %s in resource %s: %v", e.Operation, e.Resource, e.Wrapped))
+}
+
+// redactorOf returns the Redactor of the rest.Client whose request failed with err, or redact.Default() if it is
+// unknown.
+func redactorOf(err error) *redact.Redactor {
+	if redactor, ok := rest.RedactorFromError(err); ok {
+		return redactor
+	}
+	var apiErr APIError
+	if errors.As(err, &apiErr) && apiErr.redactor != nil {
+		return apiErr.redactor
+	}
+	return redact.Default()
 }
 
 func (e ClientError) Unwrap() error {
@@ -74,6 +157,9 @@ func (e ValidationError) Error() string {
 	return fmt.Sprintf("validation failed for field %s in resource %s.", e.Field, e.Resource)
 }
 
+// Is reports whether target is ErrValidation.
+func (e ValidationError) Is(target error) bool { return target == ErrValidation }
+
 // RuntimeError represents an error that occurs when the program makes assumptions
 // about the structure or content of data returned from an API, which are required
 // for constructing a valid follow-up request. It wraps the original error and
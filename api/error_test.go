@@ -18,11 +18,17 @@ package api_test
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestClientError_Error(t *testing.T) {
@@ -49,6 +55,30 @@ func TestClientError_Error(t *testing.T) {
 
 		assert.Equal(t, expectedErrorMessage, err.Error())
 	})
+	t.Run("redacts credentials", func(t *testing.T) {
+		expectedErrorMessage := "failed to GET in resource segments: request with header Bearer REDACTED failed"
+		err := api.ClientError{
+			Wrapped:   errors.New("request with header Bearer eyJhbGciOi failed"),
+			Operation: http.MethodGet,
+			Resource:  "segments",
+		}
+
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+}
+
+func TestClientError_RedactsSecretsOfClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	baseURL, _ := url.Parse(server.URL)
+	server.Close()
+
+	client := rest.NewClient(baseURL, nil, rest.WithRedactor(redact.Default().With(redact.Options{Secrets: []string{"client-s3cr3t"}})))
+	_, reqErr := client.GET(t.Context(), "objects/client-s3cr3t", rest.RequestOptions{})
+	require.Error(t, reqErr)
+
+	err := api.ClientError{Wrapped: reqErr, Operation: http.MethodGet, Resource: "objects"}
+	assert.NotContains(t, err.Error(), "client-s3cr3t")
+	assert.Contains(t, err.Error(), redact.Mask)
 }
 
 func TestClientError_Unwrap(t *testing.T) {
@@ -120,3 +150,59 @@ func TestRuntimeError_Unwrap(t *testing.T) {
 
 	assert.Equal(t, expectedErr, err.Unwrap())
 }
+
+func TestErrorSentinels(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		sentinel   error
+	}{
+		{http.StatusBadRequest, api.ErrValidation},
+		{http.StatusUnauthorized, api.ErrUnauthorized},
+		{http.StatusForbidden, api.ErrForbidden},
+		{http.StatusNotFound, api.ErrNotFound},
+		{http.StatusConflict, api.ErrConflict},
+		{http.StatusPreconditionFailed, api.ErrConflict},
+		{http.StatusUnprocessableEntity, api.ErrValidation},
+		{http.StatusTooManyRequests, api.ErrRateLimited},
+		{http.StatusInternalServerError, api.ErrServer},
+		{http.StatusServiceUnavailable, api.ErrServer},
+	}
+	sentinels := []error{api.ErrNotFound, api.ErrConflict, api.ErrUnauthorized, api.ErrForbidden, api.ErrRateLimited, api.ErrValidation, api.ErrServer}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			err := fmt.Errorf("failed to get object with id 42: %w", api.APIError{StatusCode: tt.statusCode})
+			for _, s := range sentinels {
+				assert.Equal(t, s == tt.sentinel, errors.Is(err, s), "errors.Is(%v)", s)
+			}
+
+			var apiErr api.APIError
+			assert.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.statusCode, apiErr.StatusCode)
+		})
+	}
+
+	t.Run("other status codes match no sentinel", func(t *testing.T) {
+		for _, s := range sentinels {
+			assert.NotErrorIs(t, api.APIError{StatusCode: http.StatusMethodNotAllowed}, s)
+		}
+	})
+
+	t.Run("client errors keep their context", func(t *testing.T) {
+		err := api.ClientError{Wrapped: api.APIError{StatusCode: http.StatusNotFound}, Operation: http.MethodGet, Resource: "segments", Identifier: "42"}
+		assert.ErrorIs(t, err, api.ErrNotFound)
+		assert.True(t, api.IsNotFoundError(err))
+		assert.Contains(t, err.Error(), "with id 42")
+	})
+
+	t.Run("validation errors match ErrValidation", func(t *testing.T) {
+		assert.ErrorIs(t, api.ValidationError{Resource: "segments", Field: "id"}, api.ErrValidation)
+
+		sentinel := api.NewValidationSentinel("id must be non-empty")
+		err := fmt.Errorf("failed to get object: %w", sentinel)
+		assert.ErrorIs(t, err, sentinel)
+		assert.ErrorIs(t, err, api.ErrValidation)
+		assert.NotErrorIs(t, err, api.ErrNotFound)
+		assert.Equal(t, "id must be non-empty", sentinel.Error())
+	})
+}
@@ -0,0 +1,129 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+// JSONOptions configure the JSON request helpers DoJSON, GetJSON, PostJSON, PutJSON and PatchJSON.
+type JSONOptions struct {
+	rest.RequestOptions
+
+	// Strict makes decoding fail if the response payload contains fields which are unknown to the response type.
+	Strict bool
+}
+
+// DoJSON sends a request with the given method to the path built by joining path and elems, see url.JoinPath.
+// If reqBody is not nil, it is encoded as JSON payload of the request. The payload of a successful response is decoded
+// into an object of Resp, which is the zero value if the response has no content.
+//
+// Failed requests return the error of the rest.Client, and non-successful (i.e. not 2xx) responses result in an APIError.
+func DoJSON[Resp any](ctx context.Context, client *rest.Client, method string, reqBody any, options JSONOptions, path string, elems ...string) (Resp, error) {
+	var zero Resp
+
+	fullPath, err := url.JoinPath(path, elems...)
+	if err != nil {
+		return zero, fmt.Errorf("failed to build request path: %w", err)
+	}
+
+	var body io.Reader
+	if reqBody != nil {
+		data, err := json.Marshal(reqBody)
+		if err != nil {
+			return zero, fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	if options.Accept == "" {
+		options.Accept = "application/json"
+	}
+
+	var httpResp *http.Response
+	switch method {
+	case http.MethodGet:
+		httpResp, err = client.GET(ctx, fullPath, options.RequestOptions)
+	case http.MethodPost:
+		httpResp, err = client.POST(ctx, fullPath, body, options.RequestOptions)
+	case http.MethodPut:
+		httpResp, err = client.PUT(ctx, fullPath, body, options.RequestOptions)
+	case http.MethodPatch:
+		httpResp, err = client.PATCH(ctx, fullPath, body, options.RequestOptions)
+	case http.MethodDelete:
+		httpResp, err = client.DELETE(ctx, fullPath, options.RequestOptions)
+	default:
+		return zero, fmt.Errorf("unsupported HTTP method %q", method)
+	}
+	if err != nil {
+		return zero, err
+	}
+
+	resp, err := NewResponseFromHTTPResponse(httpResp)
+	if err != nil {
+		return zero, err
+	}
+	if len(bytes.TrimSpace(resp.Data)) == 0 {
+		return zero, nil
+	}
+
+	if options.Strict {
+		return DecodeJSONStrict[Resp](resp)
+	}
+	return DecodeJSON[Resp](resp)
+}
+
+// GetJSON sends a GET request to the path built by joining path and elems and decodes the response into an object of
+// T. See DoJSON.
+func GetJSON[T any](ctx context.Context, client *rest.Client, options JSONOptions, path string, elems ...string) (T, error) {
+	return DoJSON[T](ctx, client, http.MethodGet, nil, options, path, elems...)
+}
+
+// PostJSON sends a POST request with the JSON encoded body to the path built by joining path and elems and decodes the
+// response into an object of Resp. See DoJSON.
+func PostJSON[Req, Resp any](ctx context.Context, client *rest.Client, body Req, options JSONOptions, path string, elems ...string) (Resp, error) {
+	return DoJSON[Resp](ctx, client, http.MethodPost, body, options, path, elems...)
+}
+
+// PutJSON sends a PUT request with the JSON encoded body to the path built by joining path and elems and decodes the
+// response into an object of Resp. See DoJSON.
+func PutJSON[Req, Resp any](ctx context.Context, client *rest.Client, body Req, options JSONOptions, path string, elems ...string) (Resp, error) {
+	return DoJSON[Resp](ctx, client, http.MethodPut, body, options, path, elems...)
+}
+
+// PatchJSON sends a PATCH request with the JSON encoded body to the path built by joining path and elems and decodes
+// the response into an object of Resp. See DoJSON.
+func PatchJSON[Req, Resp any](ctx context.Context, client *rest.Client, body Req, options JSONOptions, path string, elems ...string) (Resp, error) {
+	return DoJSON[Resp](ctx, client, http.MethodPatch, body, options, path, elems...)
+}
+
+// DecodeJSONStrict works like DecodeJSON, but fails if the Response.Data contains fields which are unknown to T.
+func DecodeJSONStrict[T any](r Response) (T, error) {
+	var t T
+	decoder := json.NewDecoder(bytes.NewReader(r.Data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&t); err != nil {
+		return t, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return t, nil
+}
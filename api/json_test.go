@@ -0,0 +1,99 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+type jsonObject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestJSONHelpers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "application/json", req.Header.Get("Accept"))
+		switch {
+		case req.URL.Path == "/objects/missing":
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error":{"code":404}}`))
+		case req.URL.Path == "/objects/extra":
+			_, _ = rw.Write([]byte(`{"id":"extra","name":"n","unknown":true}`))
+		case req.Method == http.MethodGet:
+			_, _ = rw.Write([]byte(`{"id":"` + req.URL.Path + `","name":"n"}`))
+		case req.Method == http.MethodDelete:
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			// echo the payload
+			body, _ := io.ReadAll(req.Body)
+			_, _ = rw.Write(body)
+		}
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	client := rest.NewClient(baseURL, server.Client())
+
+	t.Run("GetJSON joins the path and decodes the response", func(t *testing.T) {
+		obj, err := api.GetJSON[jsonObject](t.Context(), client, api.JSONOptions{}, "objects", "a")
+		require.NoError(t, err)
+		assert.Equal(t, jsonObject{ID: "/objects/a", Name: "n"}, obj)
+	})
+
+	t.Run("PostJSON and PutJSON encode the request", func(t *testing.T) {
+		created, err := api.PostJSON[jsonObject, jsonObject](t.Context(), client, jsonObject{Name: "new"}, api.JSONOptions{}, "objects")
+		require.NoError(t, err)
+		assert.Equal(t, jsonObject{Name: "new"}, created)
+
+		updated, err := api.PutJSON[jsonObject, map[string]string](t.Context(), client, jsonObject{ID: "a", Name: "updated"}, api.JSONOptions{}, "objects", "a")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"id": "a", "name": "updated"}, updated)
+	})
+
+	t.Run("empty responses result in the zero value", func(t *testing.T) {
+		obj, err := api.DoJSON[*jsonObject](t.Context(), client, http.MethodDelete, nil, api.JSONOptions{}, "objects", "a")
+		require.NoError(t, err)
+		assert.Nil(t, obj)
+	})
+
+	t.Run("unsuccessful responses result in an APIError", func(t *testing.T) {
+		_, err := api.GetJSON[jsonObject](t.Context(), client, api.JSONOptions{}, "objects", "missing")
+		assert.True(t, api.IsNotFoundError(err))
+	})
+
+	t.Run("strict decoding rejects unknown fields", func(t *testing.T) {
+		obj, err := api.GetJSON[jsonObject](t.Context(), client, api.JSONOptions{}, "objects", "extra")
+		require.NoError(t, err)
+		assert.Equal(t, "extra", obj.ID)
+
+		_, err = api.GetJSON[jsonObject](t.Context(), client, api.JSONOptions{Strict: true}, "objects", "extra")
+		assert.ErrorContains(t, err, "unknown field")
+	})
+
+	t.Run("unsupported methods are rejected", func(t *testing.T) {
+		_, err := api.DoJSON[jsonObject](t.Context(), client, http.MethodHead, nil, api.JSONOptions{}, "objects")
+		assert.Error(t, err)
+	})
+}
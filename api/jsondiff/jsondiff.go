@@ -0,0 +1,297 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsondiff compares JSON documents semantically, e.g. to skip updates which would not change an object, or to
+// preview the changes of an update. Documents are compared in canonical form, ignoring key order, formatting, the
+// representation of numbers and fields managed by the server.
+package jsondiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ServerManagedPaths are the paths of fields which are set by the server on most Dynatrace APIs, and therefore
+// usually ignored when comparing a configuration with an existing object.
+var ServerManagedPaths = []string{"/version", "/updateToken", "/owner", "/modificationInfo"}
+
+// Options configure how documents are compared.
+type Options struct {
+	// IgnorePaths are JSON pointers (RFC 6901) of values which are ignored, e.g. "/version". A "*" segment matches
+	// any object field or array element, e.g. "/rules/*/id".
+	IgnorePaths []string
+}
+
+// Canonicalize returns the canonical form of the given JSON document: object keys are sorted, insignificant
+// whitespace is removed and numbers are normalized, so that e.g. 1, 1.0 and 1e0 are the same. Values at ignored
+// paths are removed.
+func Canonicalize(data []byte, opts Options) ([]byte, error) {
+	v, err := parse(data, opts)
+	if err != nil {
+		return nil, err
+	}
+	return marshal(v), nil
+}
+
+// Equal returns whether the given JSON documents are equal in canonical form, see Canonicalize.
+func Equal(a, b []byte, opts Options) (bool, error) {
+	va, err := parse(a, opts)
+	if err != nil {
+		return false, err
+	}
+	vb, err := parse(b, opts)
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(va, vb), nil
+}
+
+// Operations of a Patch.
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+)
+
+// Operation is an operation of a JSON Patch (RFC 6902).
+type Operation struct {
+	Op string `json:"op"`
+	// Path is the JSON pointer (RFC 6901) of the changed value.
+	Path string `json:"path"`
+	// Value is the added or new value, in canonical form.
+	Value json.RawMessage `json:"value,omitempty"`
+	// OldValue is the removed or replaced value, in canonical form. It is not part of RFC 6902 and therefore not
+	// marshaled, but used by Patch.String.
+	OldValue json.RawMessage `json:"-"`
+}
+
+// Patch is a JSON Patch (RFC 6902) transforming one JSON document into another. Marshaling it results in the JSON
+// representation of RFC 6902.
+type Patch []Operation
+
+// String returns the Patch as human-readable diff, with one line per operation. Added values are prefixed by "+",
+// removed values by "-" and replaced values by "~", e.g. `~ /retentionDays: 35 -> 40`.
+func (p Patch) String() string {
+	var b strings.Builder
+	for _, op := range p {
+		switch op.Op {
+		case OpAdd:
+			fmt.Fprintf(&b, "+ %s: %s\n", op.Path, op.Value)
+		case OpRemove:
+			fmt.Fprintf(&b, "- %s: %s\n", op.Path, op.OldValue)
+		default:
+			fmt.Fprintf(&b, "~ %s: %s -> %s\n", op.Path, op.OldValue, op.Value)
+		}
+	}
+	return b.String()
+}
+
+// Diff returns the Patch transforming the JSON document from into the JSON document to, ignoring differences which
+// vanish in canonical form, see Canonicalize. Objects are compared field by field, and arrays element by element, so
+// that an element inserted into an array results in replacing all following elements.
+// An empty Patch means that the documents are equal.
+func Diff(from, to []byte, opts Options) (Patch, error) {
+	a, err := parse(from, opts)
+	if err != nil {
+		return nil, err
+	}
+	b, err := parse(to, opts)
+	if err != nil {
+		return nil, err
+	}
+	return diff("", a, b), nil
+}
+
+func diff(path string, a, b any) Patch {
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		var patch Patch
+		for _, key := range slices.Sorted(maps.Keys(a)) {
+			p := path + "/" + escape(key)
+			if vb, found := b[key]; found {
+				patch = append(patch, diff(p, a[key], vb)...)
+			} else {
+				patch = append(patch, Operation{Op: OpRemove, Path: p, OldValue: marshal(a[key])})
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(b)) {
+			if _, found := a[key]; !found {
+				patch = append(patch, Operation{Op: OpAdd, Path: path + "/" + escape(key), Value: marshal(b[key])})
+			}
+		}
+		return patch
+
+	case []any:
+		b, ok := b.([]any)
+		if !ok {
+			break
+		}
+		var patch Patch
+		for i := range min(len(a), len(b)) {
+			patch = append(patch, diff(path+"/"+strconv.Itoa(i), a[i], b[i])...)
+		}
+		// remove trailing elements starting with the last, so that the indices of the others stay valid
+		for i := len(a) - 1; i >= len(b); i-- {
+			patch = append(patch, Operation{Op: OpRemove, Path: path + "/" + strconv.Itoa(i), OldValue: marshal(a[i])})
+		}
+		for i := len(a); i < len(b); i++ {
+			patch = append(patch, Operation{Op: OpAdd, Path: path + "/" + strconv.Itoa(i), Value: marshal(b[i])})
+		}
+		return patch
+	}
+
+	return Patch{{Op: OpReplace, Path: path, Value: marshal(b), OldValue: marshal(a)}}
+}
+
+// parse decodes the given JSON document, normalizes its numbers and removes the ignored paths.
+func parse(data []byte, opts Options) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid JSON document: %w", err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("invalid JSON document: unexpected data after top-level value")
+	}
+
+	v = normalize(v)
+	for _, p := range opts.IgnorePaths {
+		v = remove(v, split(p))
+	}
+	return v, nil
+}
+
+// normalize replaces all numbers of the given decoded value by their canonical form.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = normalize(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = normalize(e)
+		}
+	case json.Number:
+		return canonicalNumber(v)
+	}
+	return v
+}
+
+// canonicalNumber returns the canonical form of the given number. Integer literals are kept as is, so that e.g. large
+// IDs don't lose precision. Other numbers are represented as integer if possible, or in their shortest form otherwise.
+func canonicalNumber(n json.Number) json.Number {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		if s == "-0" {
+			return "0"
+		}
+		return n
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return n
+	}
+	if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		return json.Number(strconv.FormatInt(int64(f), 10))
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// remove removes the values at the given path segments from v, and returns the resulting value.
+func remove(v any, segments []string) any {
+	if len(segments) == 0 {
+		return v
+	}
+	segment, last := segments[0], len(segments) == 1
+
+	switch v := v.(type) {
+	case map[string]any:
+		for key := range v {
+			if segment != "*" && key != segment {
+				continue
+			}
+			if last {
+				delete(v, key)
+			} else {
+				v[key] = remove(v[key], segments[1:])
+			}
+		}
+	case []any:
+		if segment == "*" {
+			if last {
+				return []any{}
+			}
+			for i, e := range v {
+				v[i] = remove(e, segments[1:])
+			}
+			return v
+		}
+		i, err := strconv.Atoi(segment)
+		if err != nil || i < 0 || i >= len(v) {
+			return v
+		}
+		if last {
+			return slices.Delete(v, i, i+1)
+		}
+		v[i] = remove(v[i], segments[1:])
+	}
+	return v
+}
+
+// split returns the unescaped segments of the given JSON pointer.
+func split(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, s := range segments {
+		segments[i] = strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+	}
+	return segments
+}
+
+// escape escapes the given key as segment of a JSON pointer.
+func escape(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// marshal returns the canonical JSON representation of the given decoded value.
+func marshal(v any) json.RawMessage {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v) // values decoded from JSON can always be encoded
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
@@ -0,0 +1,134 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsondiff_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/jsondiff"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     jsondiff.Options
+		expected string
+	}{
+		{
+			name:     "sorts keys and removes whitespace",
+			input:    `{ "b": 1, "a": { "d": [1, 2], "c": "<x>" } }`,
+			expected: `{"a":{"c":"<x>","d":[1,2]},"b":1}`,
+		},
+		{
+			name:     "normalizes numbers",
+			input:    `[1.0, 1e2, -0, 0.50, 2.5e-1, 12345678901234567890]`,
+			expected: `[1,100,0,0.5,0.25,12345678901234567890]`,
+		},
+		{
+			name:     "removes ignored paths",
+			input:    `{"version": 3, "name": "n", "rules": [{"id": "a", "v": 1}, {"id": "b", "v": 2}], "a/b": 1}`,
+			opts:     jsondiff.Options{IgnorePaths: []string{"/version", "/rules/*/id", "/a~1b", "/missing/path"}},
+			expected: `{"name":"n","rules":[{"v":1},{"v":2}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := jsondiff.Canonicalize([]byte(tt.input), tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(actual))
+		})
+	}
+
+	t.Run("rejects invalid documents", func(t *testing.T) {
+		_, err := jsondiff.Canonicalize([]byte(`{"a": 1} {}`), jsondiff.Options{})
+		assert.Error(t, err)
+
+		_, err = jsondiff.Canonicalize([]byte(`{"a": `), jsondiff.Options{})
+		assert.Error(t, err)
+	})
+}
+
+func TestEqual(t *testing.T) {
+	existing := `{"name": "n", "retentionDays": 35.0, "version": 2, "owner": "someone", "modificationInfo": {"lastModifiedTime": "now"}}`
+
+	equal, err := jsondiff.Equal([]byte(existing), []byte(`{"retentionDays": 35, "name": "n"}`), jsondiff.Options{IgnorePaths: jsondiff.ServerManagedPaths})
+	require.NoError(t, err)
+	assert.True(t, equal)
+
+	equal, err = jsondiff.Equal([]byte(existing), []byte(`{"retentionDays": 35, "name": "n"}`), jsondiff.Options{})
+	require.NoError(t, err)
+	assert.False(t, equal)
+
+	_, err = jsondiff.Equal([]byte(existing), []byte(`invalid`), jsondiff.Options{})
+	assert.Error(t, err)
+}
+
+func TestDiff(t *testing.T) {
+	from := `{"name": "n", "description": "old", "retentionDays": 35, "tags": ["a", "b", "c"], "rules": [{"v": 1}], "version": 1}`
+	to := `{"name": "n", "retentionDays": 40.0, "tags": ["a", "x"], "rules": [{"v": 1}, {"v": 2}], "enabled": null, "version": 2}`
+
+	patch, err := jsondiff.Diff([]byte(from), []byte(to), jsondiff.Options{IgnorePaths: jsondiff.ServerManagedPaths})
+	require.NoError(t, err)
+
+	assert.Equal(t, jsondiff.Patch{
+		{Op: jsondiff.OpRemove, Path: "/description", OldValue: json.RawMessage(`"old"`)},
+		{Op: jsondiff.OpReplace, Path: "/retentionDays", Value: json.RawMessage(`40`), OldValue: json.RawMessage(`35`)},
+		{Op: jsondiff.OpAdd, Path: "/rules/1", Value: json.RawMessage(`{"v":2}`)},
+		{Op: jsondiff.OpReplace, Path: "/tags/1", Value: json.RawMessage(`"x"`), OldValue: json.RawMessage(`"b"`)},
+		{Op: jsondiff.OpRemove, Path: "/tags/2", OldValue: json.RawMessage(`"c"`)},
+		{Op: jsondiff.OpAdd, Path: "/enabled", Value: json.RawMessage(`null`)},
+	}, patch)
+
+	t.Run("marshals as RFC 6902 patch", func(t *testing.T) {
+		data, err := json.Marshal(patch)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[
+			{"op": "remove", "path": "/description"},
+			{"op": "replace", "path": "/retentionDays", "value": 40},
+			{"op": "add", "path": "/rules/1", "value": {"v": 2}},
+			{"op": "replace", "path": "/tags/1", "value": "x"},
+			{"op": "remove", "path": "/tags/2"},
+			{"op": "add", "path": "/enabled", "value": null}
+		]`, string(data))
+	})
+
+	t.Run("human-readable diff", func(t *testing.T) {
+		assert.Equal(t, `- /description: "old"
+~ /retentionDays: 35 -> 40
++ /rules/1: {"v":2}
+~ /tags/1: "b" -> "x"
+- /tags/2: "c"
++ /enabled: null
+`, patch.String())
+	})
+
+	t.Run("equal documents result in an empty patch", func(t *testing.T) {
+		patch, err := jsondiff.Diff([]byte(`{"a": [1, {"b": 2.0}]}`), []byte(`{"a":[1,{"b":2}]}`), jsondiff.Options{})
+		require.NoError(t, err)
+		assert.Empty(t, patch)
+	})
+
+	t.Run("different types are replaced", func(t *testing.T) {
+		patch, err := jsondiff.Diff([]byte(`{"a": [1]}`), []byte(`{"a": {"0": 1}}`), jsondiff.Options{})
+		require.NoError(t, err)
+		assert.Equal(t, jsondiff.Patch{{Op: jsondiff.OpReplace, Path: "/a", Value: json.RawMessage(`{"0":1}`), OldValue: json.RawMessage(`[1]`)}}, patch)
+	})
+}
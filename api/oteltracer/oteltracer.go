@@ -0,0 +1,118 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package oteltracer provides a rest.Tracer backed by OpenTelemetry, so that the operations and HTTP requests of the
+// clients are exported by any OpenTelemetry SDK, e.g.:
+//
+//	factory := clients.Factory().WithTracer(oteltracer.New(otel.Tracer("my-tool")))
+//
+// Spans are started as children of the OpenTelemetry span contained in the context, so they are part of the traces
+// of the calling application.
+package oteltracer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+// New returns a rest.Tracer creating its spans using the given OpenTelemetry tracer. Spans of HTTP requests are
+// created as client spans, all others as internal spans.
+func New(tracer trace.Tracer) rest.Tracer {
+	return otelTracer{tracer: tracer}
+}
+
+type otelTracer struct {
+	tracer trace.Tracer
+}
+
+func (t otelTracer) Start(ctx context.Context, name string, attributes ...slog.Attr) (context.Context, rest.Span) {
+	kind := trace.SpanKindInternal
+	for _, a := range attributes {
+		if a.Key == rest.AttributeHTTPMethod {
+			kind = trace.SpanKindClient
+		}
+	}
+
+	ctx, s := t.tracer.Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(keyValues(attributes)...))
+	sp := span{span: s}
+	return rest.ContextWithSpan(ctx, sp), sp
+}
+
+type span struct {
+	span trace.Span
+}
+
+func (s span) SetAttributes(attributes ...slog.Attr) {
+	s.span.SetAttributes(keyValues(attributes)...)
+}
+
+func (s span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s span) End() {
+	s.span.End()
+}
+
+func (s span) SpanContext() rest.SpanContext {
+	sc := s.span.SpanContext()
+	return rest.SpanContext{TraceID: sc.TraceID(), SpanID: sc.SpanID(), Sampled: sc.IsSampled()}
+}
+
+// keyValues converts the given slog attributes to OpenTelemetry attributes. Groups are flattened, prefixing the keys
+// of their attributes with the key of the group.
+func keyValues(attributes []slog.Attr) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attributes))
+	for _, a := range attributes {
+		kvs = appendKeyValue(kvs, "", a)
+	}
+	return kvs
+}
+
+func appendKeyValue(kvs []attribute.KeyValue, prefix string, a slog.Attr) []attribute.KeyValue {
+	key := prefix + a.Key
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindGroup:
+		if a.Key != "" {
+			prefix = key + "."
+		}
+		for _, ga := range v.Group() {
+			kvs = appendKeyValue(kvs, prefix, ga)
+		}
+		return kvs
+	case slog.KindString:
+		return append(kvs, attribute.String(key, v.String()))
+	case slog.KindInt64:
+		return append(kvs, attribute.Int64(key, v.Int64()))
+	case slog.KindUint64:
+		return append(kvs, attribute.Int64(key, int64(v.Uint64())))
+	case slog.KindFloat64:
+		return append(kvs, attribute.Float64(key, v.Float64()))
+	case slog.KindBool:
+		return append(kvs, attribute.Bool(key, v.Bool()))
+	case slog.KindDuration:
+		return append(kvs, attribute.Int64(key, v.Duration().Milliseconds()))
+	default:
+		return append(kvs, attribute.String(key, fmt.Sprint(v.Any())))
+	}
+}
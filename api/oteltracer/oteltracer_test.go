@@ -0,0 +1,78 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oteltracer_test
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/oteltracer"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+func TestTracer(t *testing.T) {
+	var traceparents []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		traceparents = append(traceparents, req.Header.Get("traceparent"))
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otelTracer := provider.Tracer("test")
+
+	baseURL, _ := url.Parse(server.URL)
+	client := rest.NewClient(baseURL, server.Client(), rest.WithTracer(oteltracer.New(otelTracer)))
+
+	// spans of the client are children of the span of the application
+	ctx, parent := otelTracer.Start(t.Context(), "application")
+	ctx, end := client.StartOperation(ctx, "objects.Client.Get", slog.String("id", "42"), slog.Group("request", slog.Int("size", 1)))
+	_, err := client.GET(ctx, "objects/42", rest.RequestOptions{})
+	require.NoError(t, err)
+	end(errors.New("not found"))
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	request, operation, application := spans[0], spans[1], spans[2]
+
+	assert.Equal(t, "HTTP GET", request.Name)
+	assert.Equal(t, trace.SpanKindClient, request.SpanKind)
+	assert.Contains(t, request.Attributes, attribute.String(rest.AttributeHTTPMethod, http.MethodGet))
+	assert.Contains(t, request.Attributes, attribute.Int64(rest.AttributeStatusCode, http.StatusNotFound))
+	assert.Equal(t, operation.SpanContext.SpanID(), request.Parent.SpanID())
+	assert.Equal(t, []string{"00-" + request.SpanContext.TraceID().String() + "-" + request.SpanContext.SpanID().String() + "-01"}, traceparents)
+
+	assert.Equal(t, "objects.Client.Get", operation.Name)
+	assert.Equal(t, trace.SpanKindInternal, operation.SpanKind)
+	assert.Contains(t, operation.Attributes, attribute.String("id", "42"))
+	assert.Contains(t, operation.Attributes, attribute.Int64("request.size", 1))
+	assert.Equal(t, codes.Error, operation.Status.Code)
+	assert.Equal(t, application.SpanContext.SpanID(), operation.Parent.SpanID())
+	assert.Equal(t, application.SpanContext.TraceID(), request.SpanContext.TraceID())
+}
@@ -0,0 +1,104 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"log/slog"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+// PageFetcher fetches the page of a paginated list identified by the given cursor, which is the zero value of C for the
+// first page. It returns the cursor of the next page, and whether there is a next page at all.
+type PageFetcher[C any] func(ctx context.Context, cursor C) (page ListResponse, next C, more bool, err error)
+
+// Pages returns an iterator over the pages of a paginated list, which are fetched lazily using fetch: a page is only
+// fetched once the consumer asks for it, and no further pages are fetched once the consumer stops the iteration. If
+// fetching a page fails, the error is yielded and the iteration ends.
+//
+// Each iteration is an operation of the given rest.Client with the given name and attributes, see
+// rest.Client.StartOperation.
+func Pages[C any](ctx context.Context, client *rest.Client, operation string, fetch PageFetcher[C], attributes ...slog.Attr) iter.Seq2[ListResponse, error] {
+	return func(yield func(ListResponse, error) bool) {
+		ctx, endOperation := client.StartOperation(ctx, operation, attributes...)
+		var err error
+		defer func() { endOperation(err) }()
+
+		var cursor C
+		for more := true; more; {
+			var page ListResponse
+			if page, cursor, more, err = fetch(ctx, cursor); err != nil {
+				yield(ListResponse{}, err)
+				return
+			}
+			if !yield(page, nil) {
+				return
+			}
+		}
+	}
+}
+
+// CollectPages fetches all pages of the given iterator. If fetching any page fails, only the error is returned.
+func CollectPages(pages iter.Seq2[ListResponse, error]) (PagedListResponse, error) {
+	var res PagedListResponse
+	for page, err := range pages {
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, page)
+	}
+	return res, nil
+}
+
+// Objects returns an iterator over the objects of the given pages. The metadata of the page an object belongs to,
+// like the status code of its response, is available by iterating the pages instead.
+func Objects(pages iter.Seq2[ListResponse, error]) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		for page, err := range pages {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, o := range page.Objects {
+				if !yield(o, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// DecodeObjects returns an iterator unmarshalling the given JSON objects into T. If fetching or unmarshalling an
+// object fails, the error is yielded and the iteration ends.
+func DecodeObjects[T any](objects iter.Seq2[[]byte, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for o, err := range objects {
+			var t T
+			if err == nil {
+				err = json.Unmarshal(o, &t)
+			}
+			if err != nil {
+				yield(t, err)
+				return
+			}
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
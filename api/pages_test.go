@@ -0,0 +1,101 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+func TestPages(t *testing.T) {
+	baseURL, _ := url.Parse("http://localhost")
+	client := rest.NewClient(baseURL, nil)
+
+	// fetchPages fetches pages consisting of one object each, failing for the page with index failAt
+	fetchPages := func(count int, failAt int, fetched *[]int) api.PageFetcher[int] {
+		return func(_ context.Context, cursor int) (api.ListResponse, int, bool, error) {
+			*fetched = append(*fetched, cursor)
+			if cursor == failAt {
+				return api.ListResponse{}, 0, false, errors.New("page failed")
+			}
+			return api.ListResponse{Objects: [][]byte{[]byte(`{"index":` + strconv.Itoa(cursor) + `}`)}}, cursor + 1, cursor+1 < count, nil
+		}
+	}
+
+	t.Run("fetches all pages", func(t *testing.T) {
+		var fetched []int
+		pages, err := api.CollectPages(api.Pages(t.Context(), client, "test.List", fetchPages(3, -1, &fetched)))
+		require.NoError(t, err)
+		assert.Len(t, pages, 3)
+		assert.Equal(t, []int{0, 1, 2}, fetched)
+	})
+
+	t.Run("stops fetching once the iteration is stopped", func(t *testing.T) {
+		var fetched []int
+		for range api.Objects(api.Pages(t.Context(), client, "test.List", fetchPages(3, -1, &fetched))) {
+			break
+		}
+		assert.Equal(t, []int{0}, fetched)
+	})
+
+	t.Run("each iteration starts from the first page", func(t *testing.T) {
+		var fetched []int
+		pages := api.Pages(t.Context(), client, "test.List", fetchPages(2, -1, &fetched))
+		_, _ = api.CollectPages(pages)
+		_, _ = api.CollectPages(pages)
+		assert.Equal(t, []int{0, 1, 0, 1}, fetched)
+	})
+
+	t.Run("errors end the iteration", func(t *testing.T) {
+		var fetched []int
+		pages, err := api.CollectPages(api.Pages(t.Context(), client, "test.List", fetchPages(3, 1, &fetched)))
+		assert.ErrorContains(t, err, "page failed")
+		assert.Nil(t, pages)
+		assert.Equal(t, []int{0, 1}, fetched)
+	})
+
+	t.Run("decodes objects", func(t *testing.T) {
+		var fetched []int
+		var indices []int
+		for o, err := range api.DecodeObjects[struct{ Index int }](api.Objects(api.Pages(t.Context(), client, "test.List", fetchPages(3, -1, &fetched)))) {
+			require.NoError(t, err)
+			indices = append(indices, o.Index)
+		}
+		assert.Equal(t, []int{0, 1, 2}, indices)
+	})
+
+	t.Run("decoding errors end the iteration", func(t *testing.T) {
+		objects := func(yield func([]byte, error) bool) {
+			_ = yield([]byte(`{"index":0}`), nil) && yield([]byte(`invalid`), nil) && yield([]byte(`{"index":2}`), nil)
+		}
+
+		var errs []error
+		for _, err := range api.DecodeObjects[struct{ Index int }](objects) {
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 2)
+		assert.NoError(t, errs[0])
+		assert.Error(t, errs[1])
+	})
+}
@@ -0,0 +1,353 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"log/slog"
+	"maps"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+// PageCursor is the position of a page within a paginated list.
+type PageCursor struct {
+	// Index is the index of the page, which is 0 for the first page.
+	Index int
+	// Offset is the number of objects on the preceding pages.
+	Offset int
+	// Key is the page key identifying the page, if the PaginationStrategy uses page keys.
+	Key string
+	// URL is the URL of the page, if the PaginationStrategy follows links.
+	URL *url.URL
+}
+
+// PaginationStrategy is the way an API pages through a list, e.g. by offset or by page key.
+type PaginationStrategy interface {
+	// PageRequest returns the path and query parameters requesting the page at the given cursor, given those of the
+	// first page. If pageSize is 0, the default page size of the API is used.
+	PageRequest(cursor PageCursor, path string, query url.Values, pageSize int) (string, url.Values)
+
+	// NextPage returns the cursor of the page following the given page fetched at cursor, and whether there is one.
+	// The Index and Offset of the returned cursor are set by the Paginator.
+	NextPage(cursor PageCursor, page ListResponse) (PageCursor, bool, error)
+}
+
+// OffsetPagination pages through a list by passing the number of objects to skip, e.g. "?offset=100".
+type OffsetPagination struct {
+	// OffsetParam is the query parameter for the offset.
+	OffsetParam string
+	// PageSizeParam is the query parameter for the page size.
+	PageSizeParam string
+	// TotalCountField is the field of the response payload containing the total number of objects.
+	TotalCountField string
+}
+
+// OffsetStrategy returns the OffsetPagination using the "offset" and "limit" query parameters, and the given field of
+// the response payload as total number of objects.
+func OffsetStrategy(totalCountField string) OffsetPagination {
+	return OffsetPagination{OffsetParam: "offset", PageSizeParam: "limit", TotalCountField: totalCountField}
+}
+
+func (s OffsetPagination) PageRequest(cursor PageCursor, path string, query url.Values, pageSize int) (string, url.Values) {
+	query.Set(s.OffsetParam, strconv.Itoa(cursor.Offset))
+	if pageSize > 0 {
+		query.Set(s.PageSizeParam, strconv.Itoa(pageSize))
+	}
+	return path, query
+}
+
+func (s OffsetPagination) NextPage(cursor PageCursor, page ListResponse) (PageCursor, bool, error) {
+	var totalCount int
+	if err := unmarshalField(page.Data, s.TotalCountField, &totalCount); err != nil {
+		return PageCursor{}, false, err
+	}
+	next := PageCursor{Offset: cursor.Offset + len(page.Objects)}
+	// stop on empty pages, as the total count might change while paging
+	return next, len(page.Objects) > 0 && next.Offset < totalCount, nil
+}
+
+// PageKeyPagination pages through a list by passing the key of the next page returned along with each page, e.g.
+// "?page-key=abc".
+type PageKeyPagination struct {
+	// KeyParam is the query parameter for the page key.
+	KeyParam string
+	// PageSizeParam is the query parameter for the page size.
+	PageSizeParam string
+	// NextPageKeyField is the field of the response payload containing the key of the next page. There is no next page
+	// if it is missing, null or empty.
+	NextPageKeyField string
+	// KeyOnly defines that the page key must be the only query parameter when requesting any but the first page, as
+	// the key already encodes all parameters of the first page.
+	KeyOnly bool
+}
+
+// PageKeyStrategy returns the PageKeyPagination passing the "nextPageKey" of the response as "page-key", along with
+// the query parameters of the first page.
+func PageKeyStrategy() PageKeyPagination {
+	return PageKeyPagination{KeyParam: "page-key", PageSizeParam: "page-size", NextPageKeyField: "nextPageKey"}
+}
+
+// NextPageKeyStrategy returns the PageKeyPagination passing the "nextPageKey" of the response as only query parameter
+// "next-page-key".
+func NextPageKeyStrategy() PageKeyPagination {
+	return PageKeyPagination{KeyParam: "next-page-key", PageSizeParam: "page-size", NextPageKeyField: "nextPageKey", KeyOnly: true}
+}
+
+func (s PageKeyPagination) PageRequest(cursor PageCursor, path string, query url.Values, pageSize int) (string, url.Values) {
+	if cursor.Key == "" {
+		if pageSize > 0 {
+			query.Set(s.PageSizeParam, strconv.Itoa(pageSize))
+		}
+		return path, query
+	}
+
+	if s.KeyOnly {
+		query = url.Values{}
+	}
+	query.Set(s.KeyParam, cursor.Key)
+	return path, query
+}
+
+func (s PageKeyPagination) NextPage(_ PageCursor, page ListResponse) (PageCursor, bool, error) {
+	var nextPageKey *string
+	if err := unmarshalField(page.Data, s.NextPageKeyField, &nextPageKey); err != nil {
+		return PageCursor{}, false, err
+	}
+	if nextPageKey == nil || *nextPageKey == "" {
+		return PageCursor{}, false, nil
+	}
+	return PageCursor{Key: *nextPageKey}, true, nil
+}
+
+// LinkHeaderPagination pages through a list by following the "next" link of the Link header of each page, see
+// RFC 8288.
+type LinkHeaderPagination struct {
+	// PageSizeParam is the query parameter for the page size of the first page.
+	PageSizeParam string
+}
+
+// LinkHeaderStrategy returns the LinkHeaderPagination using the "page-size" query parameter.
+func LinkHeaderStrategy() LinkHeaderPagination {
+	return LinkHeaderPagination{PageSizeParam: "page-size"}
+}
+
+func (s LinkHeaderPagination) PageRequest(cursor PageCursor, path string, query url.Values, pageSize int) (string, url.Values) {
+	if cursor.URL != nil {
+		return cursor.URL.Path, cursor.URL.Query()
+	}
+	if pageSize > 0 {
+		query.Set(s.PageSizeParam, strconv.Itoa(pageSize))
+	}
+	return path, query
+}
+
+func (s LinkHeaderPagination) NextPage(_ PageCursor, page ListResponse) (PageCursor, bool, error) {
+	for _, header := range page.Header.Values("Link") {
+		for link := range strings.SplitSeq(header, ",") {
+			target, params, found := strings.Cut(link, ";")
+			if !found || !isNextRel(params) {
+				continue
+			}
+			next, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+			if err != nil {
+				return PageCursor{}, false, fmt.Errorf("invalid next link %q: %w", target, err)
+			}
+			if page.Request.URL != "" {
+				if current, err := url.Parse(page.Request.URL); err == nil {
+					next = current.ResolveReference(next)
+				}
+			}
+			return PageCursor{URL: next}, true, nil
+		}
+	}
+	return PageCursor{}, false, nil
+}
+
+// isNextRel returns whether the given parameters of a link contain rel="next".
+func isNextRel(params string) bool {
+	for param := range strings.SplitSeq(params, ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if strings.EqualFold(name, "rel") && strings.Contains(" "+strings.Trim(value, `"`)+" ", " next ") {
+			return true
+		}
+	}
+	return false
+}
+
+// SinglePagePagination is used for lists which are not paginated, and are returned as a single page.
+type SinglePagePagination struct{}
+
+func (SinglePagePagination) PageRequest(_ PageCursor, path string, query url.Values, _ int) (string, url.Values) {
+	return path, query
+}
+
+func (SinglePagePagination) NextPage(PageCursor, ListResponse) (PageCursor, bool, error) {
+	return PageCursor{}, false, nil
+}
+
+// PaginationOptions configure how a Paginator pages through a list.
+type PaginationOptions struct {
+	// PageSize is the number of objects requested per page. If 0, the default page size of the API is used.
+	PageSize int
+	// MaxPages is the maximum number of pages to fetch. If 0, all pages are fetched.
+	MaxPages int
+	// OnPage is called for each fetched page, e.g. to report progress.
+	OnPage func(ctx context.Context, cursor PageCursor, page ListResponse)
+}
+
+type paginationOptionsKey struct{}
+
+// ContextWithPaginationOptions returns a copy of ctx carrying PaginationOptions, which override those of the
+// Paginator of any list operation called with the returned context. Unset fields keep the values of the Paginator.
+func ContextWithPaginationOptions(ctx context.Context, opts PaginationOptions) context.Context {
+	return context.WithValue(ctx, paginationOptionsKey{}, opts)
+}
+
+// Paginator fetches the pages of a list using a PaginationStrategy. Any page request is sent by the rest.Client, so
+// that retries, rate limiting and the other middlewares of the client apply to each of them, and iterating is stopped
+// once the context is canceled.
+type Paginator struct {
+	Client   *rest.Client
+	Strategy PaginationStrategy
+
+	// Path and Options define the request of the first page.
+	Path    string
+	Options rest.RequestOptions
+
+	// ObjectsField is the field of the response payload containing the objects of a page, e.g. "items". If it is
+	// empty, the payload itself is the list of objects.
+	ObjectsField string
+	// Resource is the name of the listed resource, used in errors.
+	Resource string
+
+	// WrapError optionally wraps the errors of page requests, e.g. to add the context of the operation. Errors of
+	// unexpected response payloads are returned as RuntimeError.
+	WrapError func(err error) error
+
+	PaginationOptions
+}
+
+// Pages returns an iterator over the pages of the list, which are fetched lazily using Fetch, see Pages.
+func (p Paginator) Pages(ctx context.Context, operation string, attributes ...slog.Attr) iter.Seq2[ListResponse, error] {
+	return Pages(ctx, p.Client, operation, p.Fetch, attributes...)
+}
+
+// Fetch fetches the page at the given cursor. It implements PageFetcher.
+func (p Paginator) Fetch(ctx context.Context, cursor PageCursor) (ListResponse, PageCursor, bool, error) {
+	opts := p.optionsFrom(ctx)
+	if err := ctx.Err(); err != nil {
+		return ListResponse{}, PageCursor{}, false, p.wrapError(err)
+	}
+
+	ro := p.Options
+	ro.QueryParams = maps.Clone(p.Options.QueryParams)
+	if ro.QueryParams == nil {
+		ro.QueryParams = url.Values{}
+	}
+	var path string
+	path, ro.QueryParams = p.Strategy.PageRequest(cursor, p.Path, ro.QueryParams, opts.PageSize)
+	path = p.relativePath(path)
+
+	httpResp, err := p.Client.GET(ctx, path, ro)
+	if err != nil {
+		return ListResponse{}, PageCursor{}, false, p.wrapError(err)
+	}
+	resp, err := NewResponseFromHTTPResponse(httpResp)
+	if err != nil {
+		return ListResponse{}, PageCursor{}, false, p.wrapError(err)
+	}
+
+	var objects []json.RawMessage
+	if err := unmarshalField(resp.Data, p.ObjectsField, &objects); err != nil {
+		return ListResponse{}, PageCursor{}, false, RuntimeError{Resource: p.Resource, Reason: "unmarshalling failed", Wrapped: err}
+	}
+	page := ListResponse{Response: resp, Objects: make([][]byte, len(objects))}
+	for i, o := range objects {
+		page.Objects[i] = o
+	}
+
+	next, more, err := p.Strategy.NextPage(cursor, page)
+	if err != nil {
+		return ListResponse{}, PageCursor{}, false, RuntimeError{Resource: p.Resource, Reason: "failed to determine next page", Wrapped: err}
+	}
+	next.Index, next.Offset = cursor.Index+1, cursor.Offset+len(page.Objects)
+
+	if opts.OnPage != nil {
+		opts.OnPage(ctx, cursor, page)
+	}
+	if opts.MaxPages > 0 && next.Index >= opts.MaxPages {
+		more = false
+	}
+	return page, next, more, nil
+}
+
+// optionsFrom returns the PaginationOptions of the Paginator, overridden by those of ctx.
+func (p Paginator) optionsFrom(ctx context.Context) PaginationOptions {
+	opts := p.PaginationOptions
+	override, ok := ctx.Value(paginationOptionsKey{}).(PaginationOptions)
+	if !ok {
+		return opts
+	}
+	if override.PageSize > 0 {
+		opts.PageSize = override.PageSize
+	}
+	if override.MaxPages > 0 {
+		opts.MaxPages = override.MaxPages
+	}
+	if override.OnPage != nil {
+		opts.OnPage = override.OnPage
+	}
+	return opts
+}
+
+// relativePath strips the path of the base URL of the client from the given path, e.g. of a followed link.
+func (p Paginator) relativePath(path string) string {
+	base := strings.TrimSuffix(p.Client.BaseURL().Path, "/")
+	if base != "" && strings.HasPrefix(path, base+"/") {
+		return strings.TrimPrefix(path, base)
+	}
+	return path
+}
+
+func (p Paginator) wrapError(err error) error {
+	if p.WrapError == nil {
+		return err
+	}
+	return p.WrapError(err)
+}
+
+// unmarshalField unmarshals the given field of a JSON object into v, or the whole value if field is empty. A missing
+// field leaves v unchanged.
+func unmarshalField(data []byte, field string, v any) error {
+	if field == "" {
+		return json.Unmarshal(data, v)
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	if raw, ok := object[field]; ok {
+		return json.Unmarshal(raw, v)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+// paginatedServer serves the given number of objects in pages of the given size using the pagination style of the
+// API, and records the query of each request.
+func paginatedServer(t *testing.T, objects int, pageSize int, style string) (*rest.Client, *[]url.Values) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		queries = append(queries, query)
+
+		offset := 0
+		switch style {
+		case "offset":
+			offset, _ = strconv.Atoi(query.Get("offset"))
+		case "page-key":
+			offset, _ = strconv.Atoi(query.Get("page-key"))
+		case "next-page-key":
+			offset, _ = strconv.Atoi(query.Get("next-page-key"))
+		case "link":
+			offset, _ = strconv.Atoi(query.Get("from"))
+		}
+		end := min(offset+pageSize, objects)
+
+		var items []string
+		for i := offset; i < end; i++ {
+			items = append(items, strconv.Itoa(i))
+		}
+		nextPageKey := "null"
+		if end < objects {
+			nextPageKey = `"` + strconv.Itoa(end) + `"`
+			rw.Header().Set("Link", fmt.Sprintf(`<%s?from=%d>; rel="next", <%s>; rel="first"`, req.URL.Path, end, req.URL.Path))
+		}
+		_, _ = fmt.Fprintf(rw, `{"totalCount": %d, "nextPageKey": %s, "items": [%s]}`, objects, nextPageKey, strings.Join(items, ","))
+	}))
+	t.Cleanup(server.Close)
+
+	baseURL, _ := url.Parse(server.URL + "/base")
+	return rest.NewClient(baseURL, server.Client()), &queries
+}
+
+func collectInts(t *testing.T, pages api.PagedListResponse) []int {
+	var res []int
+	for _, o := range pages.All() {
+		i, err := strconv.Atoi(string(o))
+		require.NoError(t, err)
+		res = append(res, i)
+	}
+	return res
+}
+
+func TestPaginator(t *testing.T) {
+	all := []int{0, 1, 2, 3, 4, 5, 6}
+
+	t.Run("offset", func(t *testing.T) {
+		client, queries := paginatedServer(t, 7, 3, "offset")
+		p := api.Paginator{Client: client, Strategy: api.OffsetStrategy("totalCount"), Path: "objects", ObjectsField: "items"}
+
+		pages, err := api.CollectPages(p.Pages(t.Context(), "test.List"))
+		require.NoError(t, err)
+		assert.Equal(t, all, collectInts(t, pages))
+		assert.Len(t, pages, 3)
+		assert.Equal(t, []string{"0", "3", "6"}, []string{(*queries)[0].Get("offset"), (*queries)[1].Get("offset"), (*queries)[2].Get("offset")})
+	})
+
+	t.Run("page-key repeats the query of the first page", func(t *testing.T) {
+		client, queries := paginatedServer(t, 7, 3, "page-key")
+		p := api.Paginator{
+			Client:            client,
+			Strategy:          api.PageKeyStrategy(),
+			Path:              "objects",
+			Options:           rest.RequestOptions{QueryParams: url.Values{"filter": {"a"}}},
+			ObjectsField:      "items",
+			PaginationOptions: api.PaginationOptions{PageSize: 3},
+		}
+
+		pages, err := api.CollectPages(p.Pages(t.Context(), "test.List"))
+		require.NoError(t, err)
+		assert.Equal(t, all, collectInts(t, pages))
+		assert.Equal(t, []url.Values{
+			{"filter": {"a"}, "page-size": {"3"}},
+			{"filter": {"a"}, "page-key": {"3"}},
+			{"filter": {"a"}, "page-key": {"6"}},
+		}, *queries)
+	})
+
+	t.Run("next-page-key sends only the key", func(t *testing.T) {
+		client, queries := paginatedServer(t, 7, 3, "next-page-key")
+		p := api.Paginator{
+			Client:            client,
+			Strategy:          api.NextPageKeyStrategy(),
+			Path:              "objects",
+			Options:           rest.RequestOptions{QueryParams: url.Values{"filter": {"a"}}},
+			ObjectsField:      "items",
+			PaginationOptions: api.PaginationOptions{PageSize: 3},
+		}
+
+		pages, err := api.CollectPages(p.Pages(t.Context(), "test.List"))
+		require.NoError(t, err)
+		assert.Equal(t, all, collectInts(t, pages))
+		assert.Equal(t, []url.Values{
+			{"filter": {"a"}, "page-size": {"3"}},
+			{"next-page-key": {"3"}},
+			{"next-page-key": {"6"}},
+		}, *queries)
+	})
+
+	t.Run("link header", func(t *testing.T) {
+		client, queries := paginatedServer(t, 7, 3, "link")
+		p := api.Paginator{Client: client, Strategy: api.LinkHeaderStrategy(), Path: "objects", ObjectsField: "items"}
+
+		pages, err := api.CollectPages(p.Pages(t.Context(), "test.List"))
+		require.NoError(t, err)
+		assert.Equal(t, all, collectInts(t, pages))
+		assert.Len(t, *queries, 3)
+		assert.True(t, strings.HasSuffix(pages[2].Request.URL, "/base/objects?from=6"), "the base path must not be duplicated")
+	})
+
+	t.Run("single page", func(t *testing.T) {
+		client, queries := paginatedServer(t, 7, 3, "offset")
+		p := api.Paginator{Client: client, Strategy: api.SinglePagePagination{}, Path: "objects", ObjectsField: "items"}
+
+		pages, err := api.CollectPages(p.Pages(t.Context(), "test.List"))
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2}, collectInts(t, pages))
+		assert.Len(t, *queries, 1)
+	})
+
+	t.Run("payload is the list of objects", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			_, _ = rw.Write([]byte(`[0, 1, 2]`))
+		}))
+		defer server.Close()
+		baseURL, _ := url.Parse(server.URL)
+		p := api.Paginator{Client: rest.NewClient(baseURL, server.Client()), Strategy: api.SinglePagePagination{}, Path: "objects"}
+
+		pages, err := api.CollectPages(p.Pages(t.Context(), "test.List"))
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2}, collectInts(t, pages))
+	})
+
+	t.Run("max pages and page hook", func(t *testing.T) {
+		client, _ := paginatedServer(t, 7, 3, "offset")
+		var cursors []api.PageCursor
+		p := api.Paginator{
+			Client:       client,
+			Strategy:     api.OffsetStrategy("totalCount"),
+			Path:         "objects",
+			ObjectsField: "items",
+			PaginationOptions: api.PaginationOptions{
+				MaxPages: 2,
+				OnPage: func(_ context.Context, cursor api.PageCursor, _ api.ListResponse) {
+					cursors = append(cursors, cursor)
+				},
+			},
+		}
+
+		pages, err := api.CollectPages(p.Pages(t.Context(), "test.List"))
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, collectInts(t, pages))
+		assert.Equal(t, []api.PageCursor{{Index: 0, Offset: 0}, {Index: 1, Offset: 3}}, cursors)
+	})
+
+	t.Run("options of the context override those of the paginator", func(t *testing.T) {
+		client, queries := paginatedServer(t, 7, 3, "page-key")
+		p := api.Paginator{
+			Client:            client,
+			Strategy:          api.PageKeyStrategy(),
+			Path:              "objects",
+			ObjectsField:      "items",
+			PaginationOptions: api.PaginationOptions{PageSize: 3},
+		}
+
+		ctx := api.ContextWithPaginationOptions(t.Context(), api.PaginationOptions{PageSize: 10, MaxPages: 1})
+		pages, err := api.CollectPages(p.Pages(ctx, "test.List"))
+		require.NoError(t, err)
+		assert.Len(t, pages, 1)
+		assert.Equal(t, "10", (*queries)[0].Get("page-size"))
+	})
+
+	t.Run("stops once the context is canceled", func(t *testing.T) {
+		client, queries := paginatedServer(t, 7, 3, "offset")
+		p := api.Paginator{Client: client, Strategy: api.OffsetStrategy("totalCount"), Path: "objects", ObjectsField: "items"}
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+		var err error
+		for _, err = range p.Pages(ctx, "test.List") {
+			cancel()
+		}
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, *queries, 1)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/missing" {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = rw.Write([]byte(`{"items": "invalid"}`))
+		}))
+		defer server.Close()
+		baseURL, _ := url.Parse(server.URL)
+		client := rest.NewClient(baseURL, server.Client())
+		wrapped := errors.New("wrapped")
+
+		p := api.Paginator{
+			Client:       client,
+			Strategy:     api.PageKeyStrategy(),
+			Path:         "missing",
+			ObjectsField: "items",
+			Resource:     "objects",
+			WrapError:    func(err error) error { return errors.Join(wrapped, err) },
+		}
+		_, err := api.CollectPages(p.Pages(t.Context(), "test.List"))
+		assert.ErrorIs(t, err, api.ErrNotFound)
+		assert.ErrorIs(t, err, wrapped)
+
+		p.Path = "invalid"
+		_, err = api.CollectPages(p.Pages(t.Context(), "test.List"))
+		assert.ErrorAs(t, err, &api.RuntimeError{})
+		assert.NotErrorIs(t, err, wrapped)
+	})
+}
@@ -0,0 +1,305 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact masks secrets such as tokens, client secrets and credential values in headers, URLs, JSON bodies and
+// messages before they are logged, passed to listeners or included in errors.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Mask replaces all redacted values.
+const Mask = "REDACTED"
+
+var (
+	// DefaultHeaders match the headers which are redacted by default.
+	DefaultHeaders = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)^(proxy-)?authorization$`),
+		regexp.MustCompile(`(?i)^(set-)?cookie$`),
+		regexp.MustCompile(`(?i)(token|secret|api-?key|password)`),
+	}
+
+	// DefaultFields match the query parameters and JSON fields which are redacted by default. They match whole names
+	// only, so that fields like "updateToken", "tokenId" or "credentialsId" are kept.
+	DefaultFields = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)^((access|api|auth|bearer|id|platform|refresh|session)[-_]?)?token$`),
+		regexp.MustCompile(`(?i)^(client[-_]?)?secret$`),
+		regexp.MustCompile(`(?i)^(passw(or)?d|pwd)$`),
+		regexp.MustCompile(`(?i)^(api|private)[-_]?key$`),
+		regexp.MustCompile(`(?i)^(authorization|credentials?)$`),
+	}
+
+	// authSchemes matches the credentials of Authorization header values appearing in free text.
+	authSchemes = regexp.MustCompile(`(?i)\b(bearer|api-token|basic)\s+[^\s"',;]+`)
+)
+
+// Options configure a Redactor.
+type Options struct {
+	// Headers match the names of headers whose values are redacted.
+	Headers []*regexp.Regexp
+	// QueryParams match the names of query parameters whose values are redacted.
+	QueryParams []*regexp.Regexp
+	// Fields match the names of JSON object fields whose values are redacted, at any depth. They are also applied
+	// to the keys of slog attributes.
+	Fields []*regexp.Regexp
+	// Secrets are values which are redacted wherever they appear, e.g. tokens or values of credential vault entries.
+	Secrets []string
+}
+
+// DefaultOptions returns the Options of the default Redactor, which redacts authorization and cookie headers and
+// all headers whose names contain e.g. "token", "secret" or "password", and query parameters and JSON fields named
+// e.g. "token", "apiToken", "clientSecret" or "password".
+func DefaultOptions() Options {
+	return Options{
+		Headers:     DefaultHeaders,
+		QueryParams: DefaultFields,
+		Fields:      DefaultFields,
+	}
+}
+
+// Redactor masks sensitive values. A nil Redactor doesn't redact anything.
+// A Redactor is safe for concurrent use.
+type Redactor struct {
+	opts Options
+
+	mu      sync.RWMutex
+	secrets []string
+}
+
+// New creates a new Redactor using the given options. Use New(Options{}) for a Redactor which only redacts
+// explicitly added secrets.
+func New(opts Options) *Redactor {
+	r := &Redactor{opts: opts}
+	r.AddSecrets(opts.Secrets...)
+	return r
+}
+
+// Names returns patterns matching exactly the given names, ignoring case. It allows configuring a Redactor using
+// plain header, query parameter or field names.
+func Names(names ...string) []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, 0, len(names))
+	for _, n := range names {
+		patterns = append(patterns, regexp.MustCompile(`(?i)^`+regexp.QuoteMeta(n)+`$`))
+	}
+	return patterns
+}
+
+var defaultRedactor atomic.Pointer[Redactor]
+
+func init() {
+	defaultRedactor.Store(New(DefaultOptions()))
+}
+
+// Default returns the default Redactor, which is used for errors, log output and HTTPListener events unless
+// configured otherwise.
+func Default() *Redactor {
+	return defaultRedactor.Load()
+}
+
+// SetDefault replaces the default Redactor. Passing nil disables the default redaction entirely.
+func SetDefault(r *Redactor) {
+	defaultRedactor.Store(r)
+}
+
+// AddSecrets registers values which are redacted wherever they appear. Empty and already registered values are
+// ignored.
+func (r *Redactor) AddSecrets(values ...string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, v := range values {
+		if v != "" && !slices.Contains(r.secrets, v) {
+			r.secrets = append(r.secrets, v)
+		}
+	}
+}
+
+// With returns a new Redactor combining the options and secrets of r with the given options.
+func (r *Redactor) With(opts Options) *Redactor {
+	if r == nil {
+		return New(opts)
+	}
+
+	r.mu.RLock()
+	secrets := append(append([]string(nil), r.secrets...), opts.Secrets...)
+	r.mu.RUnlock()
+
+	return New(Options{
+		Headers:     append(append([]*regexp.Regexp(nil), r.opts.Headers...), opts.Headers...),
+		QueryParams: append(append([]*regexp.Regexp(nil), r.opts.QueryParams...), opts.QueryParams...),
+		Fields:      append(append([]*regexp.Regexp(nil), r.opts.Fields...), opts.Fields...),
+		Secrets:     secrets,
+	})
+}
+
+// String returns s with all secrets and the credentials of authorization schemes like "Bearer <token>" masked.
+func (r *Redactor) String(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+
+	r.mu.RLock()
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Mask)
+	}
+	r.mu.RUnlock()
+
+	return authSchemes.ReplaceAllString(s, "$1 "+Mask)
+}
+
+// Header returns a copy of header with the values of all matching headers masked.
+func (r *Redactor) Header(header http.Header) http.Header {
+	if r == nil || header == nil {
+		return header
+	}
+
+	redacted := make(http.Header, len(header))
+	for name, values := range header {
+		masked := make([]string, len(values))
+		for i, v := range values {
+			if matchesAny(r.opts.Headers, name) {
+				masked[i] = Mask
+			} else {
+				masked[i] = r.String(v)
+			}
+		}
+		redacted[name] = masked
+	}
+	return redacted
+}
+
+// URL returns a copy of u with the values of matching query parameters, user info and secrets masked.
+func (r *Redactor) URL(u *url.URL) *url.URL {
+	if r == nil || u == nil {
+		return u
+	}
+
+	redacted := *u
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			redacted.User = url.UserPassword(u.User.Username(), Mask)
+		}
+	}
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		changed := false
+		for name, values := range query {
+			for i, v := range values {
+				masked := r.String(v)
+				if matchesAny(r.opts.QueryParams, name) {
+					masked = Mask
+				}
+				if masked != v {
+					values[i] = masked
+					changed = true
+				}
+			}
+		}
+		if changed {
+			redacted.RawQuery = query.Encode()
+		}
+	}
+	if path := r.String(u.Path); path != u.Path {
+		redacted.Path = path
+		redacted.RawPath = ""
+	}
+	return &redacted
+}
+
+// URLString redacts the given URL like URL. Strings which aren't valid URLs are redacted like String.
+func (r *Redactor) URLString(s string) string {
+	if r == nil {
+		return s
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return r.String(s)
+	}
+	return r.URL(u).String()
+}
+
+// Body returns a copy of a request or response body with the values of matching JSON fields and all secrets masked.
+// Bodies which aren't JSON are redacted like String. If nothing needs to be redacted, body is returned unchanged.
+func (r *Redactor) Body(body []byte) []byte {
+	if r == nil || len(body) == 0 {
+		return body
+	}
+
+	if len(r.opts.Fields) > 0 && json.Valid(body) {
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err == nil && r.redactJSON(v) {
+			if redacted, err := json.Marshal(v); err == nil {
+				body = redacted
+			}
+		}
+	}
+
+	if s := string(body); r.String(s) != s {
+		return []byte(r.String(s))
+	}
+	return body
+}
+
+// redactJSON masks the values of matching fields in v in place and reports whether anything was masked.
+func (r *Redactor) redactJSON(v any) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if matchesAny(r.opts.Fields, key) {
+				if value != Mask {
+					v[key] = Mask
+					changed = true
+				}
+				continue
+			}
+			changed = r.redactJSON(value) || changed
+		}
+	case []any:
+		for _, e := range v {
+			changed = r.redactJSON(e) || changed
+		}
+	}
+	return changed
+}
+
+// Field reports whether the value of a JSON field or log attribute with the given name is redacted.
+func (r *Redactor) Field(name string) bool {
+	return r != nil && matchesAny(r.opts.Fields, name)
+}
+
+func matchesAny(patterns []*regexp.Regexp, name string) bool {
+	for _, p := range patterns {
+		if p.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
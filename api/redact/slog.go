@@ -0,0 +1,106 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+)
+
+// Handler is a slog.Handler redacting log records before passing them to another handler.
+// Attributes with matching keys are masked, and the message and all string, error, header and URL values are redacted.
+type Handler struct {
+	next     slog.Handler
+	redactor func() *Redactor
+}
+
+// NewHandler returns a Handler redacting records using r before passing them to next.
+// If r is nil, the current Default Redactor is used for each record.
+//
+//	slog.SetDefault(slog.New(redact.NewHandler(slog.NewTextHandler(os.Stderr, nil), nil)))
+func NewHandler(next slog.Handler, r *Redactor) *Handler {
+	h := &Handler{next: next, redactor: Default}
+	if r != nil {
+		h.redactor = func() *Redactor { return r }
+	}
+	return h
+}
+
+// Enabled implements slog.Handler.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	r := h.redactor()
+	redacted := slog.NewRecord(record.Time, record.Level, r.String(record.Message), record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(r.Attr(a))
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	r := h.redactor()
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = r.Attr(a)
+	}
+	return &Handler{next: h.next.WithAttrs(redacted), redactor: h.redactor}
+}
+
+// WithGroup implements slog.Handler.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name), redactor: h.redactor}
+}
+
+// Attr returns a redacted copy of the given slog attribute. It can also be called from
+// slog.HandlerOptions.ReplaceAttr to redact the output of a handler which isn't wrapped by a Handler.
+func (r *Redactor) Attr(a slog.Attr) slog.Attr {
+	if r == nil {
+		return a
+	}
+	if r.Field(a.Key) {
+		return slog.String(a.Key, Mask)
+	}
+
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, r.String(v.String()))
+	case slog.KindGroup:
+		group := v.Group()
+		redacted := make([]any, len(group))
+		for i, ga := range group {
+			redacted[i] = r.Attr(ga)
+		}
+		return slog.Group(a.Key, redacted...)
+	case slog.KindAny:
+		switch av := v.Any().(type) {
+		case http.Header:
+			return slog.Any(a.Key, r.Header(av))
+		case *url.URL:
+			return slog.String(a.Key, r.URL(av).String())
+		case error:
+			return slog.String(a.Key, r.String(av.Error()))
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
@@ -20,7 +20,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 )
 
@@ -65,7 +67,7 @@ func NewResponseFromHTTPResponse(httpResponse *http.Response) (Response, error)
 	defer httpResponse.Body.Close()
 
 	// httpResponse.Body is always non-nil. For more sse documentation for http.Response
-	body, err := io.ReadAll(httpResponse.Body)
+	body, err := readAll(httpResponse.Body)
 	if err != nil {
 		return Response{}, NewAPIErrorFromResponseAndBody(httpResponse, body)
 	}
@@ -84,6 +86,52 @@ func NewResponseFromHTTPResponse(httpResponse *http.Response) (Response, error)
 	return resp, nil
 }
 
+// readAll reads r to completion. Bodies returned by a rest.Client know their size and are read from their start
+// without intermediate reallocations.
+func readAll(r io.Reader) ([]byte, error) {
+	sized, ok := r.(interface {
+		io.Seeker
+		Size() int64
+	})
+	if !ok {
+		return io.ReadAll(r)
+	}
+
+	if _, err := sized.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	data := make([]byte, sized.Size())
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// StreamResponse is a successful API response whose body is not read into memory, e.g. to process large objects
+// returned by a rest.Client in streaming mode. The caller must close the Body.
+type StreamResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       io.ReadCloser
+	Request    rest.RequestInfo
+}
+
+// NewStreamResponseFromHTTPResponse converts a http.Response to a StreamResponse without reading its body.
+// Any non-successful (i.e. not 2xx) status code results in an APIError, in which case the body is read and closed.
+func NewStreamResponseFromHTTPResponse(httpResponse *http.Response) (StreamResponse, error) {
+	if !rest.IsSuccess(httpResponse) {
+		defer httpResponse.Body.Close()
+		return StreamResponse{}, NewAPIErrorFromResponse(httpResponse)
+	}
+
+	return StreamResponse{
+		StatusCode: httpResponse.StatusCode,
+		Header:     httpResponse.Header,
+		Body:       httpResponse.Body,
+		Request:    NewRequestInfoFromRequest(httpResponse.Request),
+	}, nil
+}
+
 func NewRequestInfoFromRequest(request *http.Request) rest.RequestInfo {
 	var method, url string
 	if request != nil {
@@ -120,25 +168,40 @@ type ListResponse struct {
 }
 
 // APIError represents an error returned by an API with associated information.
+// The standard Dynatrace error payload contained in its Body can be accessed via Code, Message, ConstraintViolations
+// and ErrorDetails.
 type APIError struct {
-	StatusCode int              `json:"statusCode"` // StatusCode is the HTTP response status code returned by the API.
-	Body       []byte           `json:"body"`       // Body is the HTTP payload returned by the API.
-	Request    rest.RequestInfo `json:"request"`    // Request is information about the original request that led to this response error.
+	StatusCode int              `json:"statusCode"`           // StatusCode is the HTTP response status code returned by the API.
+	Body       []byte           `json:"body"`                 // Body is the HTTP payload returned by the API.
+	Request    rest.RequestInfo `json:"request"`              // Request is information about the original request that led to this response error.
+	Header     http.Header      `json:"-"`                    // Header are the HTTP response headers returned by the API.
+	RequestID  string           `json:"requestId,omitempty"`  // RequestID is the ID the server assigned to the request, see rest.RequestIDHeader.
+	RetryAfter time.Duration    `json:"retryAfter,omitempty"` // RetryAfter is the delay the server requested before retrying a 429 or 503 response, or any response carrying a Retry-After header.
+
+	redactor *redact.Redactor // redactor of the rest.Client which sent the request, if known
 }
 
 func NewAPIErrorFromResponseAndBody(resp *http.Response, body []byte) APIError {
-	return APIError{
+	apiErr := APIError{
 		StatusCode: resp.StatusCode,
 		Body:       body,
 		Request:    NewRequestInfoFromRequest(resp.Request),
+		Header:     resp.Header,
+		RequestID:  resp.Header.Get(rest.RequestIDHeader),
+	}
+	if resp.Request != nil {
+		apiErr.redactor = rest.RedactorFromContext(resp.Request.Context())
+	}
+	// rate-limit headers are sent with every response of some APIs, so they only request a delay if the server is
+	// overloaded or explicitly asks for one
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable || resp.Header.Get("Retry-After") != "" {
+		apiErr.RetryAfter, _ = rest.ServerRequestedDelay(resp.Header, time.Now())
 	}
+	return apiErr
 }
 
 func NewAPIErrorFromResponse(resp *http.Response) error {
-	apiErr := APIError{
-		StatusCode: resp.StatusCode,
-		Request:    NewRequestInfoFromRequest(resp.Request),
-	}
+	apiErr := NewAPIErrorFromResponseAndBody(resp, nil)
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -149,12 +212,24 @@ func NewAPIErrorFromResponse(resp *http.Response) error {
 }
 
 // Error returns a string representation of the APIError, providing details about the failed API request.
-// It includes the HTTP method, URL, status code, and response body.
+// It includes the HTTP method, URL, status code, and response body, which are redacted using the Redactor of the
+// rest.Client which sent the request, or redact.Default() if it is unknown.
 //
 // Returns:
 // - string: A string representing the error message.
 func (r APIError) Error() string {
-	return fmt.Sprintf("API request HTTP %s %s failed with status code %d: %s", r.Request.Method, r.Request.URL, r.StatusCode, string(r.Body))
+	redactor := r.redactor
+	if redactor == nil {
+		redactor = redact.Default()
+	}
+	return fmt.Sprintf("API request HTTP %s %s failed with status code %d: %s", r.Request.Method, redactor.URLString(r.Request.URL), r.StatusCode, string(redactor.Body(r.Body)))
+}
+
+// Is reports whether target is the sentinel error matching the StatusCode of the APIError, e.g. ErrNotFound for 404
+// responses, so that the kind of error can be checked using errors.Is.
+func (r APIError) Is(target error) bool {
+	sentinel := sentinelForStatusCode(r.StatusCode)
+	return sentinel != nil && target == sentinel
 }
 
 func (r APIError) Is4xxError() bool {
@@ -205,7 +280,7 @@ func DecodePaginatedJSONObjects[T any](p PagedListResponse) ([]T, error) {
 	return res, nil
 }
 
+// IsNotFoundError reports whether err matches ErrNotFound.
 func IsNotFoundError(err error) bool {
-	var apiErr APIError
-	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+	return errors.Is(err, ErrNotFound)
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 )
 
 func TestResponse_DecodeJSON(t *testing.T) {
@@ -277,6 +278,47 @@ func TestAsResponseOrError(t *testing.T) {
 	})
 }
 
+func TestNewStreamResponseFromHTTPResponse(t *testing.T) {
+	t.Run("successful response keeps body open", func(t *testing.T) {
+		body := &stubReaderCloser{reader: strings.NewReader("content")}
+		resp, err := api.NewStreamResponseFromHTTPResponse(&http.Response{StatusCode: http.StatusOK, Body: body})
+		require.NoError(t, err)
+		assert.False(t, body.closed)
+
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(data))
+	})
+
+	t.Run("unsuccessful response returns APIError", func(t *testing.T) {
+		body := &stubReaderCloser{reader: strings.NewReader("not found")}
+		_, err := api.NewStreamResponseFromHTTPResponse(&http.Response{StatusCode: http.StatusNotFound, Body: body})
+
+		var apiErr api.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, "not found", string(apiErr.Body))
+		assert.True(t, body.closed)
+	})
+}
+
+func TestAPIError_Error(t *testing.T) {
+	t.Run("contains request, status code and body", func(t *testing.T) {
+		err := api.APIError{StatusCode: http.StatusNotFound, Body: []byte(`{"error":"not found"}`), Request: rest.RequestInfo{Method: http.MethodGet, URL: "https://example.com/api?page=1"}}
+		assert.Equal(t, `API request HTTP GET https://example.com/api?page=1 failed with status code 404: {"error":"not found"}`, err.Error())
+	})
+
+	t.Run("redacts sensitive query parameters and body fields", func(t *testing.T) {
+		err := api.APIError{
+			StatusCode: http.StatusBadRequest,
+			Body:       []byte(`{"error":"invalid","clientSecret":"secret-value"}`),
+			Request:    rest.RequestInfo{Method: http.MethodPost, URL: "https://example.com/api?Api-Token=secret-token"},
+		}
+		assert.NotContains(t, err.Error(), "secret-value")
+		assert.NotContains(t, err.Error(), "secret-token")
+		assert.Contains(t, err.Error(), "invalid")
+	})
+}
+
 func TestIsNotFoundError(t *testing.T) {
 	t.Run("404 error returns true", func(t *testing.T) {
 		err := api.APIError{StatusCode: http.StatusNotFound}
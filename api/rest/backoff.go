@@ -0,0 +1,101 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+const defaultBackoffMultiplier = 2.0
+
+// Backoff computes how long to wait before the next retry of a failed request.
+type Backoff interface {
+	// Next returns the delay before the given retry attempt (starting at 1).
+	// previous is the delay returned for the preceding attempt, or zero before the first retry.
+	Next(attempt int, previous time.Duration) time.Duration
+}
+
+// ConstantBackoff waits the same Delay before every retry.
+type ConstantBackoff struct {
+	Delay time.Duration
+}
+
+// Next returns the constant Delay.
+func (b ConstantBackoff) Next(int, time.Duration) time.Duration {
+	return b.Delay
+}
+
+// ExponentialBackoff grows the delay by Multiplier for every retry, starting with InitialDelay.
+// If Jitter is set (0 < Jitter <= 1), each delay is randomly reduced by up to that fraction to spread out
+// retries of concurrent requests.
+type ExponentialBackoff struct {
+	InitialDelay time.Duration
+	Multiplier   float64 // Multiplier applied per attempt, defaults to 2 if not set.
+	Jitter       float64 // Jitter is the fraction of each delay that is randomized.
+}
+
+// Next returns InitialDelay * Multiplier^(attempt-1), randomized by Jitter.
+func (b ExponentialBackoff) Next(attempt int, _ time.Duration) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = defaultBackoffMultiplier
+	}
+
+	delay := float64(b.InitialDelay) * math.Pow(multiplier, float64(max(attempt-1, 0)))
+	if delay >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+
+	if jitter := min(b.Jitter, 1); jitter > 0 {
+		delay -= delay * jitter * rand.Float64() //nolint:gosec // jitter does not need a cryptographically secure source
+	}
+	return time.Duration(delay)
+}
+
+// DecorrelatedJitterBackoff implements the "decorrelated jitter" strategy: each delay is picked randomly between
+// BaseDelay and three times the previous delay. Use RetryOptions.MaxDelay to cap its growth.
+type DecorrelatedJitterBackoff struct {
+	BaseDelay time.Duration
+}
+
+// Next returns a random delay between BaseDelay and 3 * previous.
+func (b DecorrelatedJitterBackoff) Next(_ int, previous time.Duration) time.Duration {
+	upper := max(previous*3, b.BaseDelay)
+	if upper <= b.BaseDelay {
+		return b.BaseDelay
+	}
+	return b.BaseDelay + rand.N(upper-b.BaseDelay) //nolint:gosec // jitter does not need a cryptographically secure source
+}
+
+// sleep blocks for the given duration or until the context is done, whichever happens first.
+// It returns the context's error if the wait was aborted.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
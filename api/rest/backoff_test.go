@@ -0,0 +1,181 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantBackoff(t *testing.T) {
+	b := ConstantBackoff{Delay: time.Second}
+	assert.Equal(t, time.Second, b.Next(1, 0))
+	assert.Equal(t, time.Second, b.Next(5, time.Second))
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Run("grows by default multiplier of 2", func(t *testing.T) {
+		b := ExponentialBackoff{InitialDelay: 100 * time.Millisecond}
+		assert.Equal(t, 100*time.Millisecond, b.Next(1, 0))
+		assert.Equal(t, 200*time.Millisecond, b.Next(2, 0))
+		assert.Equal(t, 800*time.Millisecond, b.Next(4, 0))
+	})
+
+	t.Run("uses custom multiplier", func(t *testing.T) {
+		b := ExponentialBackoff{InitialDelay: time.Second, Multiplier: 3}
+		assert.Equal(t, 9*time.Second, b.Next(3, 0))
+	})
+
+	t.Run("jitter reduces delay by at most the given fraction", func(t *testing.T) {
+		b := ExponentialBackoff{InitialDelay: time.Second, Jitter: 0.5}
+		for range 100 {
+			d := b.Next(2, 0)
+			assert.GreaterOrEqual(t, d, time.Second)
+			assert.LessOrEqual(t, d, 2*time.Second)
+		}
+	})
+
+	t.Run("does not overflow", func(t *testing.T) {
+		b := ExponentialBackoff{InitialDelay: time.Hour}
+		assert.Positive(t, b.Next(1000, 0))
+	})
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	b := DecorrelatedJitterBackoff{BaseDelay: 100 * time.Millisecond}
+	assert.Equal(t, 100*time.Millisecond, b.Next(1, 0))
+
+	for range 100 {
+		d := b.Next(2, time.Second)
+		assert.GreaterOrEqual(t, d, 100*time.Millisecond)
+		assert.Less(t, d, 3*time.Second)
+	}
+}
+
+func TestRetryOptions_NextDelay(t *testing.T) {
+	t.Run("falls back to DelayAfterRetry", func(t *testing.T) {
+		assert.Equal(t, time.Second, RetryOptions{DelayAfterRetry: time.Second}.nextDelay(3, 0))
+	})
+
+	t.Run("caps delay at MaxDelay", func(t *testing.T) {
+		o := RetryOptions{Backoff: ExponentialBackoff{InitialDelay: time.Second}, MaxDelay: 3 * time.Second}
+		assert.Equal(t, 2*time.Second, o.nextDelay(2, 0))
+		assert.Equal(t, 3*time.Second, o.nextDelay(3, 0))
+	})
+}
+
+func TestSleep(t *testing.T) {
+	t.Run("returns after duration", func(t *testing.T) {
+		assert.NoError(t, sleep(t.Context(), time.Millisecond))
+	})
+
+	t.Run("aborts when context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		start := time.Now()
+		err := sleep(ctx, time.Hour)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestServerRequestedDelay(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		header    http.Header
+		wantDelay time.Duration
+		wantOK    bool
+	}{
+		{
+			name:      "Retry-After in seconds",
+			header:    http.Header{"Retry-After": {"5"}},
+			wantDelay: 5 * time.Second,
+			wantOK:    true,
+		},
+		{
+			name:      "Retry-After as HTTP-date",
+			header:    http.Header{"Retry-After": {now.Add(2 * time.Second).Format(http.TimeFormat)}},
+			wantDelay: 2 * time.Second,
+			wantOK:    true,
+		},
+		{
+			name:      "Retry-After in the past",
+			header:    http.Header{"Retry-After": {now.Add(-time.Minute).Format(http.TimeFormat)}},
+			wantDelay: 0,
+			wantOK:    true,
+		},
+		{
+			name:      "X-RateLimit-Reset",
+			header:    http.Header{"X-Ratelimit-Reset": {strconv.FormatInt(now.Add(3*time.Second).Unix(), 10)}},
+			wantDelay: 3 * time.Second,
+			wantOK:    true,
+		},
+		{
+			name: "Retry-After takes precedence over X-RateLimit-Reset",
+			header: http.Header{
+				"Retry-After":       {"1"},
+				"X-Ratelimit-Reset": {strconv.FormatInt(now.Add(3*time.Second).Unix(), 10)},
+			},
+			wantDelay: time.Second,
+			wantOK:    true,
+		},
+		{
+			name:   "invalid header values are ignored",
+			header: http.Header{"Retry-After": {"soon"}, "X-Ratelimit-Reset": {"later"}},
+			wantOK: false,
+		},
+		{
+			name:   "no headers",
+			header: http.Header{},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := ServerRequestedDelay(tt.header, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantDelay, delay)
+		})
+	}
+}
+
+func TestRetryOptions_DelayFor(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": {"3600"}}}
+
+	t.Run("server delay is capped at default", func(t *testing.T) {
+		assert.Equal(t, time.Minute, RetryOptions{DelayAfterRetry: time.Second}.delayFor(resp, 1, 0))
+	})
+
+	t.Run("server delay is capped at MaxRetryAfter", func(t *testing.T) {
+		assert.Equal(t, 10*time.Second, RetryOptions{MaxRetryAfter: 10 * time.Second}.delayFor(resp, 1, 0))
+	})
+
+	t.Run("server delay is ignored if configured", func(t *testing.T) {
+		assert.Equal(t, time.Second, RetryOptions{DelayAfterRetry: time.Second, IgnoreRetryAfter: true}.delayFor(resp, 1, 0))
+	})
+
+	t.Run("backoff is used without server delay", func(t *testing.T) {
+		assert.Equal(t, time.Second, RetryOptions{DelayAfterRetry: time.Second}.delayFor(&http.Response{}, 1, 0))
+	})
+}
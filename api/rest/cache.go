@@ -0,0 +1,357 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedResponse is a response stored in a Cache together with its validators.
+type CachedResponse struct {
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         []byte      `json:"body,omitempty"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	StoredAt     time.Time   `json:"storedAt"`
+}
+
+// Cache stores responses of GET requests by URL. See WithCache.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the response stored for the given key, if any. The context is the one of the request, e.g. to log
+	// using LoggerFromContext.
+	Get(ctx context.Context, key string) (CachedResponse, bool)
+	// Set stores the response for the given key, replacing any previously stored response.
+	Set(ctx context.Context, key string, resp CachedResponse)
+	// Delete removes the response stored for the given key, if any.
+	Delete(ctx context.Context, key string)
+}
+
+// WithCache enables caching of GET responses carrying an ETag or Last-Modified header in the given Cache.
+//
+// Cached responses are never served without asking the server: subsequent GET requests to the same URL are sent as
+// conditional requests using If-None-Match and If-Modified-Since. If the server replies with 304 Not Modified, the
+// cached response is returned instead, so callers always receive the complete, current object.
+// Successful PUT, POST, PATCH and DELETE requests invalidate the cached responses of all URLs with the same scheme,
+// host and path, regardless of their query parameters: e.g. a PUT to /buckets/b?optimistic-locking-version=2
+// invalidates the cached response of /buckets/b. To do so, the time of the invalidation is stored in the Cache, too.
+// Individual requests can bypass the cache via RequestOptions.BypassCache.
+//
+// The cache key is the request URL only, so a Cache must not be shared between clients authenticating as different
+// users, or the responses of one might be revealed to the other.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// CacheMiddleware returns a Middleware sending GET requests as conditional requests using the validators of the
+// responses stored in the given Cache, and serving them from the Cache if the server replies with 304 Not Modified.
+func CacheMiddleware(cache Cache) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			if requestOptionsFrom(req).BypassCache {
+				return next(req)
+			}
+
+			key := cacheKey(req)
+			if req.Method != http.MethodGet {
+				resp, err := next(req)
+				if err == nil && resp.StatusCode < http.StatusBadRequest {
+					cache.Delete(req.Context(), key)
+					cache.Set(req.Context(), invalidationKey(req), CachedResponse{StoredAt: time.Now()})
+				}
+				return resp, err
+			}
+
+			// explicitly conditional requests are the caller's business
+			if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+				return next(req)
+			}
+
+			cached, found := cache.Get(req.Context(), key)
+			if found && invalidated(req, cache, cached) {
+				cache.Delete(req.Context(), key)
+				found = false
+			}
+			if found {
+				req = req.Clone(req.Context())
+				if cached.ETag != "" {
+					req.Header.Set("If-None-Match", cached.ETag)
+				}
+				if cached.LastModified != "" {
+					req.Header.Set("If-Modified-Since", cached.LastModified)
+				}
+			}
+
+			resp, err := next(req)
+			if err != nil {
+				return resp, err
+			}
+
+			switch {
+			case found && resp.StatusCode == http.StatusNotModified:
+				addSpanAttributes(req.Context(), slog.Bool(AttributeCacheHit, true))
+				return cachedHTTPResponse(cache, key, cached, req, resp), nil
+			case resp.StatusCode == http.StatusOK:
+				storeResponse(req.Context(), cache, key, resp)
+			case found && resp.StatusCode < http.StatusInternalServerError:
+				cache.Delete(req.Context(), key)
+			}
+			return resp, nil
+		}
+	}
+}
+
+// cacheKey returns the key of the cached response of the given request.
+func cacheKey(req *http.Request) string {
+	return req.URL.String()
+}
+
+// invalidationKey returns the key of the time the cached responses of the URLs sharing the scheme, host and path of
+// the given request were invalidated. The prefix keeps it apart from the keys of cached responses.
+func invalidationKey(req *http.Request) string {
+	u := *req.URL
+	u.RawQuery, u.ForceQuery, u.Fragment, u.RawFragment = "", false, "", ""
+	return "invalidated " + u.String()
+}
+
+// invalidated returns whether the cached response of req was stored before the last invalidation of its path.
+func invalidated(req *http.Request, cache Cache, cached CachedResponse) bool {
+	invalidation, found := cache.Get(req.Context(), invalidationKey(req))
+	return found && !cached.StoredAt.After(invalidation.StoredAt)
+}
+
+// storeResponse stores resp in the cache if it carries a validator and may be stored.
+func storeResponse(ctx context.Context, cache Cache, key string, resp *http.Response) {
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return
+	}
+	if strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-store") {
+		cache.Delete(ctx, key)
+		return
+	}
+
+	body, err := readBody(resp.Body)
+	if err != nil {
+		return
+	}
+	cache.Set(ctx, key, CachedResponse{
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Body:         bytes.Clone(body),
+		ETag:         etag,
+		LastModified: lastModified,
+		StoredAt:     time.Now(),
+	})
+}
+
+// cachedHTTPResponse returns the cached response answering req, updated with the headers of the 304 response.
+func cachedHTTPResponse(cache Cache, key string, cached CachedResponse, req *http.Request, notModified *http.Response) *http.Response {
+	_ = notModified.Body.Close()
+
+	header := cached.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	for name, values := range notModified.Header {
+		header[name] = values
+	}
+	if etag := notModified.Header.Get("ETag"); etag != "" {
+		cached.ETag = etag
+	}
+	if lastModified := notModified.Header.Get("Last-Modified"); lastModified != "" {
+		cached.LastModified = lastModified
+	}
+	cached.Header = header.Clone()
+	cached.StoredAt = time.Now()
+	cache.Set(req.Context(), key, cached)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cached.StatusCode, http.StatusText(cached.StatusCode)),
+		StatusCode:    cached.StatusCode,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          newReusableReader(cached.Body),
+		ContentLength: int64(len(cached.Body)),
+		Request:       req,
+	}
+}
+
+// MemoryCache is a Cache keeping responses in memory. If a maximum number of entries is set, the least recently used
+// responses are evicted.
+type MemoryCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type memoryCacheEntry struct {
+	key  string
+	resp CachedResponse
+}
+
+// NewMemoryCache creates a new MemoryCache holding at most maxEntries responses. If maxEntries is 0 or less, the
+// number of responses is not limited.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Get implements Cache.
+func (m *MemoryCache) Get(_ context.Context, key string) (CachedResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return CachedResponse{}, false
+	}
+	m.lru.MoveToFront(e)
+	return e.Value.(*memoryCacheEntry).resp, true
+}
+
+// Set implements Cache.
+func (m *MemoryCache) Set(_ context.Context, key string, resp CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.entries[key]; ok {
+		e.Value.(*memoryCacheEntry).resp = resp
+		m.lru.MoveToFront(e)
+		return
+	}
+
+	m.entries[key] = m.lru.PushFront(&memoryCacheEntry{key: key, resp: resp})
+	if m.maxEntries > 0 && m.lru.Len() > m.maxEntries {
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Delete implements Cache.
+func (m *MemoryCache) Delete(_ context.Context, key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.entries[key]; ok {
+		m.lru.Remove(e)
+		delete(m.entries, key)
+	}
+}
+
+// Len returns the number of cached responses.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+// DiskCache is a Cache storing responses as files in a directory, so that they survive across runs.
+// Failures to read or write the directory are logged using the logger of the request, see LoggerFromContext, and
+// treated as cache misses.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache creates a new DiskCache storing responses in the given directory, which is created if necessary.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// Get implements Cache.
+func (d *DiskCache) Get(ctx context.Context, key string) (CachedResponse, bool) {
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			LoggerFromContext(ctx).DebugContext(ctx, "Failed to read cached response", slog.String("error", err.Error()))
+		}
+		return CachedResponse{}, false
+	}
+
+	var resp CachedResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		LoggerFromContext(ctx).DebugContext(ctx, "Failed to parse cached response", slog.String("error", err.Error()))
+		return CachedResponse{}, false
+	}
+	return resp, true
+}
+
+// Set implements Cache. The file is replaced atomically, so that concurrent readers never see partial responses.
+func (d *DiskCache) Set(ctx context.Context, key string, resp CachedResponse) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		LoggerFromContext(ctx).DebugContext(ctx, "Failed to marshal cached response", slog.String("error", err.Error()))
+		return
+	}
+
+	tmp, err := os.CreateTemp(d.dir, "*.tmp")
+	if err != nil {
+		LoggerFromContext(ctx).DebugContext(ctx, "Failed to write cached response", slog.String("error", err.Error()))
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), d.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		LoggerFromContext(ctx).DebugContext(ctx, "Failed to write cached response", slog.String("error", err.Error()))
+	}
+}
+
+// Delete implements Cache.
+func (d *DiskCache) Delete(ctx context.Context, key string) {
+	if err := os.Remove(d.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		LoggerFromContext(ctx).DebugContext(ctx, "Failed to delete cached response", slog.String("error", err.Error()))
+	}
+}
+
+// path returns the file of the given key. Keys are hashed, as URLs are no valid file names.
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,215 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newETagServer(t *testing.T, body *string, conditional *int, full *int) *url.URL {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		etag := `"` + *body + `"`
+		switch {
+		case req.Method != http.MethodGet:
+			*body = "updated"
+			rw.WriteHeader(http.StatusNoContent)
+		case req.Header.Get("If-None-Match") == etag:
+			*conditional++
+			rw.Header().Set("ETag", etag)
+			rw.WriteHeader(http.StatusNotModified)
+		default:
+			*full++
+			rw.Header().Set("ETag", etag)
+			rw.Header().Set("Content-Type", "application/json")
+			_, _ = rw.Write([]byte(`{"value":"` + *body + `"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	baseURL, _ := url.Parse(server.URL)
+	return baseURL
+}
+
+func TestClient_WithCache(t *testing.T) {
+	caches := map[string]func(t *testing.T) Cache{
+		"memory": func(t *testing.T) Cache { return NewMemoryCache(0) },
+		"disk": func(t *testing.T) Cache {
+			c, err := NewDiskCache(t.TempDir())
+			require.NoError(t, err)
+			return c
+		},
+	}
+
+	for name, newCache := range caches {
+		t.Run(name, func(t *testing.T) {
+			body, conditional, full := "initial", 0, 0
+			baseURL := newETagServer(t, &body, &conditional, &full)
+			tracer := NewInMemoryTracer()
+			client := NewClient(baseURL, nil, WithCache(newCache(t)), WithTracer(tracer))
+
+			resp, err := client.GET(t.Context(), "/object", RequestOptions{})
+			require.NoError(t, err)
+			assertBody(t, `{"value":"initial"}`, resp)
+
+			// unmodified objects are served from the cache
+			resp, err = client.GET(t.Context(), "/object", RequestOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			assertBody(t, `{"value":"initial"}`, resp)
+			assert.Equal(t, 1, full)
+			assert.Equal(t, 1, conditional)
+			spans := tracer.Spans()
+			hit, ok := spans[len(spans)-1].Attribute(AttributeCacheHit)
+			assert.True(t, ok && hit.Bool())
+
+			// the cache can be bypassed
+			resp, err = client.GET(t.Context(), "/object", RequestOptions{BypassCache: true})
+			require.NoError(t, err)
+			assertBody(t, `{"value":"initial"}`, resp)
+			assert.Equal(t, 2, full)
+
+			// writes invalidate the cached response
+			_, err = client.PUT(t.Context(), "/object", strings.NewReader(`{}`), RequestOptions{})
+			require.NoError(t, err)
+			resp, err = client.GET(t.Context(), "/object", RequestOptions{})
+			require.NoError(t, err)
+			assertBody(t, `{"value":"updated"}`, resp)
+			assert.Equal(t, 3, full)
+			assert.Equal(t, 1, conditional)
+
+			// writes invalidate the cached responses of the path regardless of the query
+			_, err = client.GET(t.Context(), "/object", RequestOptions{QueryParams: url.Values{"add-fields": {"all"}}})
+			require.NoError(t, err)
+			assert.Equal(t, 4, full)
+			_, err = client.PUT(t.Context(), "/object", strings.NewReader(`{}`), RequestOptions{QueryParams: url.Values{"optimistic-locking-version": {"1"}}})
+			require.NoError(t, err)
+			_, err = client.GET(t.Context(), "/object", RequestOptions{})
+			require.NoError(t, err)
+			_, err = client.GET(t.Context(), "/object", RequestOptions{QueryParams: url.Values{"add-fields": {"all"}}})
+			require.NoError(t, err)
+			assert.Equal(t, 6, full)
+			assert.Equal(t, 1, conditional)
+		})
+	}
+}
+
+func TestCacheMiddleware_DoesNotStore(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		status int
+	}{
+		{"responses without validators", http.Header{}, http.StatusOK},
+		{"responses marked no-store", http.Header{"Etag": {`"1"`}, "Cache-Control": {"no-store"}}, http.StatusOK},
+		{"unsuccessful responses", http.Header{"Etag": {`"1"`}}, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewMemoryCache(0)
+			handler := CacheMiddleware(cache)(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: tt.status, Header: tt.header, Body: http.NoBody, Request: req}, nil
+			})
+
+			_, err := handler(httptest.NewRequest(http.MethodGet, "https://example.com/object", nil))
+			require.NoError(t, err)
+			assert.Zero(t, cache.Len())
+		})
+	}
+}
+
+func TestCacheMiddleware_KeepsExplicitConditionalRequests(t *testing.T) {
+	cache := NewMemoryCache(0)
+	cache.Set(t.Context(), "https://example.com/object", CachedResponse{StatusCode: http.StatusOK, ETag: `"cached"`})
+
+	handler := CacheMiddleware(cache)(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, `"own"`, req.Header.Get("If-None-Match"))
+		return &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{}, Body: http.NoBody, Request: req}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/object", nil)
+	req.Header.Set("If-None-Match", `"own"`)
+	resp, err := handler(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set(t.Context(), "a", CachedResponse{ETag: "a"})
+	cache.Set(t.Context(), "b", CachedResponse{ETag: "b"})
+	_, _ = cache.Get(t.Context(), "a")
+	cache.Set(t.Context(), "c", CachedResponse{ETag: "c"})
+
+	_, ok := cache.Get(t.Context(), "b")
+	assert.False(t, ok)
+	_, ok = cache.Get(t.Context(), "a")
+	assert.True(t, ok)
+	_, ok = cache.Get(t.Context(), "c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestDiskCache_SurvivesRestarts(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir)
+	require.NoError(t, err)
+	cache.Set(t.Context(), "https://example.com/object", CachedResponse{StatusCode: http.StatusOK, Body: []byte("body"), ETag: `"1"`})
+
+	cache, err = NewDiskCache(dir)
+	require.NoError(t, err)
+	resp, ok := cache.Get(t.Context(), "https://example.com/object")
+	require.True(t, ok)
+	assert.Equal(t, []byte("body"), resp.Body)
+	assert.Equal(t, `"1"`, resp.ETag)
+
+	cache.Delete(t.Context(), "https://example.com/object")
+	_, ok = cache.Get(t.Context(), "https://example.com/object")
+	assert.False(t, ok)
+}
+
+func TestDiskCache_LogsUsingLoggerOfClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("ETag", `"1"`)
+		_, _ = rw.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir)
+	require.NoError(t, err)
+	// a corrupt entry for the URL of the request
+	require.NoError(t, os.WriteFile(cache.path(server.URL), []byte("invalid"), 0o600))
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(baseURL, nil, WithCache(cache), WithLogger(logger))
+
+	_, err = client.GET(t.Context(), "", RequestOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "Failed to parse cached response")
+}
@@ -0,0 +1,289 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFailureThreshold    = 5
+	defaultCoolDown            = 30 * time.Second
+	defaultHalfOpenMaxRequests = 1
+)
+
+// ErrCircuitOpen is returned for requests rejected because the circuit breaker of their target host is open.
+// Use errors.Is to check for it; errors.As with a *CircuitOpenError gives access to details.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is the error returned for requests rejected by a CircuitBreaker.
+type CircuitOpenError struct {
+	// Host is the host the circuit is open for.
+	Host string
+	// OpenUntil is the time after which trial requests are permitted again.
+	OpenUntil time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s for host %q until %s", ErrCircuitOpen, e.Host, e.OpenUntil.Format(time.RFC3339))
+}
+
+// Is makes errors.Is(err, ErrCircuitOpen) return true for a CircuitOpenError.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitState is the state of the circuit of a single host.
+type CircuitState int
+
+const (
+	// CircuitClosed permits all requests. This is the initial state.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects all requests with ErrCircuitOpen until the cool-down has passed.
+	CircuitOpen
+	// CircuitHalfOpen permits a limited number of trial requests, which close the circuit on success or open it again
+	// on failure.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitStateChange describes a transition of the circuit of a host.
+type CircuitStateChange struct {
+	Host string
+	From CircuitState
+	To   CircuitState
+	Time time.Time
+}
+
+// CircuitBreakerOptions configure a CircuitBreaker.
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failed requests to a host after which its circuit opens.
+	// Defaults to 5.
+	FailureThreshold int
+	// CoolDown is the time an open circuit rejects requests before trial requests are permitted. Defaults to 30s.
+	CoolDown time.Duration
+	// HalfOpenMaxRequests is the number of concurrent trial requests permitted while a circuit is half-open.
+	// Defaults to 1.
+	HalfOpenMaxRequests int
+	// IsFailure decides whether a request failed. By default, requests failing with an error and responses with a
+	// 5xx status code are failures. Requests aborted because their context was cancelled or exceeded its deadline are
+	// never considered.
+	IsFailure func(resp *http.Response, err error) bool
+	// OnStateChange is optionally called whenever the circuit of a host changes its state.
+	// It is called synchronously and must not block.
+	OnStateChange func(change CircuitStateChange)
+	// Clock is used to measure the cool-down. Defaults to the real time.
+	Clock Clock
+}
+
+// CircuitBreaker tracks failing requests per target host and fails requests to hosts which are considered broken fast
+// with ErrCircuitOpen, instead of letting every caller exhaust its retries.
+// A single CircuitBreaker may be shared between Clients.
+type CircuitBreaker struct {
+	opts CircuitBreakerOptions
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state      CircuitState
+	failures   int       // consecutive failures while closed
+	openUntil  time.Time // end of the cool-down while open
+	trials     int       // trial requests in flight while half-open
+	generation uint64    // incremented with each state change, so that outdated results are ignored
+}
+
+// NewCircuitBreaker creates a new CircuitBreaker with the given options.
+func NewCircuitBreaker(opts CircuitBreakerOptions) *CircuitBreaker {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = defaultFailureThreshold
+	}
+	if opts.CoolDown <= 0 {
+		opts.CoolDown = defaultCoolDown
+	}
+	if opts.HalfOpenMaxRequests <= 0 {
+		opts.HalfOpenMaxRequests = defaultHalfOpenMaxRequests
+	}
+	if opts.IsFailure == nil {
+		opts.IsFailure = isCircuitFailure
+	}
+	if opts.Clock == nil {
+		opts.Clock = realtimeClock{}
+	}
+
+	return &CircuitBreaker{
+		opts:     opts,
+		circuits: make(map[string]*circuit),
+	}
+}
+
+// WithCircuitBreaker protects the requests of the Client with the given CircuitBreaker.
+// Each attempt to send a request is checked, so that retries of requests to a broken host fail fast as well.
+func WithCircuitBreaker(cb *CircuitBreaker) Option {
+	return func(c *Client) {
+		c.circuitBreaker = cb
+	}
+}
+
+// State returns the current state of the circuit of the given host.
+func (cb *CircuitBreaker) State(host string) CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if c, ok := cb.circuits[host]; ok {
+		cb.checkCoolDown(host, c)
+		return c.state
+	}
+	return CircuitClosed
+}
+
+// allow checks whether a request to the given host is permitted. If so, it returns the generation of the circuit the
+// result of the request must be reported for.
+func (cb *CircuitBreaker) allow(host string) (uint64, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c, ok := cb.circuits[host]
+	if !ok {
+		c = &circuit{}
+		cb.circuits[host] = c
+	}
+	cb.checkCoolDown(host, c)
+
+	switch c.state {
+	case CircuitOpen:
+		return 0, &CircuitOpenError{Host: host, OpenUntil: c.openUntil}
+	case CircuitHalfOpen:
+		if c.trials >= cb.opts.HalfOpenMaxRequests {
+			return 0, &CircuitOpenError{Host: host, OpenUntil: c.openUntil}
+		}
+		c.trials++
+	}
+	return c.generation, nil
+}
+
+// record reports the result of a request permitted by allow.
+func (cb *CircuitBreaker) record(host string, generation uint64, failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c := cb.circuits[host]
+	if c.generation != generation {
+		return // the state changed while the request was in flight
+	}
+
+	switch c.state {
+	case CircuitClosed:
+		if !failed {
+			c.failures = 0
+			return
+		}
+		c.failures++
+		if c.failures >= cb.opts.FailureThreshold {
+			cb.open(host, c)
+		}
+	case CircuitHalfOpen:
+		c.trials--
+		if failed {
+			cb.open(host, c)
+		} else {
+			cb.transition(host, c, CircuitClosed)
+		}
+	case CircuitOpen:
+		// cannot happen as the generation changes when opening
+	}
+}
+
+// abandon frees the slot of a trial request permitted by allow, without judging its result.
+func (cb *CircuitBreaker) abandon(host string, generation uint64) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if c := cb.circuits[host]; c.generation == generation && c.state == CircuitHalfOpen {
+		c.trials--
+	}
+}
+
+// checkCoolDown moves an open circuit whose cool-down has passed to half-open. cb.mu must be held.
+func (cb *CircuitBreaker) checkCoolDown(host string, c *circuit) {
+	if c.state == CircuitOpen && !cb.opts.Clock.Now().Before(c.openUntil) {
+		cb.transition(host, c, CircuitHalfOpen)
+	}
+}
+
+// open opens the circuit for the configured cool-down. cb.mu must be held.
+func (cb *CircuitBreaker) open(host string, c *circuit) {
+	c.openUntil = cb.opts.Clock.Now().Add(cb.opts.CoolDown)
+	cb.transition(host, c, CircuitOpen)
+}
+
+// transition changes the state of the circuit and notifies OnStateChange. cb.mu must be held.
+func (cb *CircuitBreaker) transition(host string, c *circuit, to CircuitState) {
+	from := c.state
+	c.state = to
+	c.failures = 0
+	c.trials = 0
+	c.generation++
+
+	if cb.opts.OnStateChange != nil {
+		cb.opts.OnStateChange(CircuitStateChange{Host: host, From: from, To: to, Time: cb.opts.Clock.Now()})
+	}
+}
+
+// isCircuitFailure is the default of CircuitBreakerOptions.IsFailure.
+func isCircuitFailure(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// CircuitBreakerMiddleware returns a Middleware rejecting requests with a CircuitOpenError while the circuit of their
+// target host is open, and reporting the result of all other requests to the given CircuitBreaker.
+func CircuitBreakerMiddleware(cb *CircuitBreaker) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			host := req.URL.Host
+			generation, err := cb.allow(host)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := next(req)
+			if req.Context().Err() != nil {
+				// the caller gave up, e.g. by cancelling or because of its own deadline, which says nothing about the
+				// host; attempts timing out due to RequestOptions.AttemptTimeout are still recorded as failures
+				cb.abandon(host, generation)
+			} else {
+				cb.record(host, generation, cb.opts.IsFailure(resp, err))
+			}
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithCircuitBreaker(t *testing.T) {
+	healthy := false
+	apiHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		apiHits++
+		if healthy {
+			rw.WriteHeader(http.StatusOK)
+			return
+		}
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+
+	clock := &testClock{currentTime: time.Now()}
+	var changes []CircuitStateChange
+	cb := NewCircuitBreaker(CircuitBreakerOptions{
+		FailureThreshold: 3,
+		CoolDown:         time.Minute,
+		Clock:            clock,
+		OnStateChange:    func(change CircuitStateChange) { changes = append(changes, change) },
+	})
+	client := NewClient(baseURL, nil,
+		WithCircuitBreaker(cb),
+		WithRetryOptions(&RetryOptions{MaxRetries: 5, ShouldRetryFunc: RetryIfNotSuccess}),
+	)
+
+	// the circuit opens after three failed attempts, aborting the retries of the first call
+	_, err := client.GET(t.Context(), "", RequestOptions{})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	var openErr *CircuitOpenError
+	require.True(t, errors.As(err, &openErr))
+	assert.Equal(t, baseURL.Host, openErr.Host)
+	assert.Equal(t, 3, apiHits)
+	assert.Equal(t, CircuitOpen, cb.State(baseURL.Host))
+
+	// further calls fail fast
+	_, err = client.GET(t.Context(), "", RequestOptions{})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 3, apiHits)
+
+	// after the cool-down, a trial request is permitted and closes the circuit on success
+	clock.currentTime = clock.currentTime.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, cb.State(baseURL.Host))
+	healthy = true
+	resp, err := client.GET(t.Context(), "", RequestOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, CircuitClosed, cb.State(baseURL.Host))
+
+	require.Len(t, changes, 3)
+	assert.Equal(t, []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed}, []CircuitState{changes[0].To, changes[1].To, changes[2].To})
+	assert.Equal(t, baseURL.Host, changes[0].Host)
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	clock := &testClock{currentTime: time.Now()}
+	cb := NewCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 1, CoolDown: time.Minute, Clock: clock})
+
+	gen, err := cb.allow("host")
+	require.NoError(t, err)
+	cb.record("host", gen, true)
+	assert.Equal(t, CircuitOpen, cb.State("host"))
+
+	clock.currentTime = clock.currentTime.Add(time.Minute)
+
+	t.Run("permits only HalfOpenMaxRequests trial requests", func(t *testing.T) {
+		trial, err := cb.allow("host")
+		require.NoError(t, err)
+		_, err = cb.allow("host")
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+
+		cb.abandon("host", trial)
+	})
+
+	t.Run("failed trial request opens the circuit again", func(t *testing.T) {
+		trial, err := cb.allow("host")
+		require.NoError(t, err)
+		cb.record("host", trial, true)
+		assert.Equal(t, CircuitOpen, cb.State("host"))
+	})
+}
+
+func TestCircuitBreaker_IsPerHost(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 1})
+
+	gen, err := cb.allow("broken")
+	require.NoError(t, err)
+	cb.record("broken", gen, true)
+
+	_, err = cb.allow("broken")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	_, err = cb.allow("healthy")
+	assert.NoError(t, err)
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 2})
+
+	for _, failed := range []bool{true, false, true, false} {
+		gen, err := cb.allow("host")
+		require.NoError(t, err)
+		cb.record("host", gen, failed)
+	}
+	assert.Equal(t, CircuitClosed, cb.State("host"))
+}
+
+func TestCircuitBreakerMiddleware_Timeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		<-req.Context().Done()
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+
+	t.Run("deadline of the caller is not a failure", func(t *testing.T) {
+		cb := NewCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 1})
+		client := NewClient(baseURL, nil, WithCircuitBreaker(cb))
+
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.GET(ctx, "", RequestOptions{})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, CircuitClosed, cb.State(baseURL.Host))
+	})
+
+	t.Run("attempt timeout is a failure", func(t *testing.T) {
+		cb := NewCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 1})
+		client := NewClient(baseURL, nil, WithCircuitBreaker(cb))
+
+		_, err := client.GET(t.Context(), "", RequestOptions{AttemptTimeout: 50 * time.Millisecond})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, CircuitOpen, cb.State(baseURL.Host))
+	})
+}
@@ -23,10 +23,11 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"slices"
 	"sync"
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
 )
 
 // RequestOptions are additional options that should be applied
@@ -47,12 +48,46 @@ type RequestOptions struct {
 	CustomShouldRetryFunc RetryFunc
 
 	// DelayAfterRetry optionally overrides the DelayAfterRetry of
-	// the RetryOptions specified for the client.
+	// the RetryOptions specified for the client. If set, the request is
+	// retried with this constant delay instead of the client's Backoff.
 	DelayAfterRetry *time.Duration
 
 	// MaxRetries optionally overrides the MaxRetries of
 	// the RetryOptions specified for the client.
 	MaxRetries *int
+
+	// Endpoint optionally names the endpoint template of the request,
+	// e.g. "/platform/document/v1/documents/{id}". It is used to label
+	// metrics, see WithMetrics. If not set, the path of the request is used.
+	Endpoint string
+
+	// Headers are HTTP headers that shall be set for this request only.
+	// They take precedence over the custom headers of the client with the
+	// same name, see Client.SetHeader.
+	Headers http.Header
+
+	// Accept is the "Accept" HTTP header that shall be used during the request.
+	// It takes precedence over an "Accept" header given in Headers.
+	Accept string
+
+	// Timeout optionally limits the total duration of the request, including
+	// all retries and the waits between them.
+	Timeout time.Duration
+
+	// AttemptTimeout optionally limits the duration of each individual attempt.
+	// An attempt exceeding it fails with a timeout error, which is retried like
+	// other transport errors (see RetryOptions.ShouldRetryOnErrorFunc) as long as
+	// the overall Timeout, or the deadline of the request's context, permits.
+	AttemptTimeout time.Duration
+
+	// Idempotency optionally makes a POST request safe to retry, see Idempotency. If it is not set, the Idempotency
+	// given via ContextWithIdempotency is used.
+	Idempotency Idempotency
+
+	// BypassCache skips the response cache of the client for the request,
+	// i.e. no conditional request is sent and the response is not stored.
+	// See WithCache.
+	BypassCache bool
 }
 
 // Option represents a functional Option for the Client.
@@ -66,6 +101,8 @@ func WithConcurrentRequestLimit(maxConcurrent int) Option {
 }
 
 // WithTimeout sets the request timeout for the Client.
+// The timeout is only applied if no custom http.Client is passed to NewClient.
+// Individual requests can be limited via RequestOptions.Timeout and RequestOptions.AttemptTimeout.
 func WithTimeout(timeout time.Duration) Option {
 	return func(c *Client) {
 		c.timeout = timeout
@@ -88,7 +125,7 @@ func WithHTTPListener(listener *HTTPListener) Option {
 
 // WithRateLimiter activates a RateLimiter for the Client.
 // The RateLimiter will block subsequent Client calls after a 429 status code is received until the mandated reset time is
-// reached. If the server should not reply with an X-RateLimit-Reset header, a default delay is enforced.
+// reached. If the server should reply with neither a Retry-After nor an X-RateLimit-Reset header, a default delay is enforced.
 // Note that a Client with RateLimiter will not automatically retry an API call after a limit was hit, but return the
 // Too Many Requests 429 Response to you.
 // If the Client should retry on errors, configure RetryOptions as well.
@@ -110,6 +147,22 @@ type Client struct {
 	retryOptions             *RetryOptions             // Retry options (optional)
 	httpListener             *HTTPListener             // HTTP listener component (optional)
 	rateLimiter              *RateLimiter              // Rate limiter component (optional)
+
+	tracer  Tracer           // Tracer component (optional)
+	metrics MetricsCollector // Metrics collector component (optional)
+
+	circuitBreaker *CircuitBreaker     // Circuit breaker component (optional)
+	recorder       *Recorder           // Recorder component (optional)
+	cache          Cache               // Response cache (optional)
+	coalescer      *coalescer          // Coalescer of identical GET requests (optional)
+	streaming      *StreamingOptions   // Streaming mode options (optional)
+	idempotency    *IdempotencyOptions // Idempotency key options (optional)
+	dryRun         *DryRunOptions      // Dry-run mode options (optional)
+	logger         *slog.Logger        // Logger of the client (optional)
+	redactor       *redact.Redactor    // Redactor of the client (optional)
+
+	middlewares     []Middleware // Custom middlewares (optional)
+	middlewareOrder []Stage      // Order of the middleware chain (optional)
 }
 
 // NewClient creates a new instance of the Client with specified options.
@@ -125,65 +178,108 @@ func NewClient(baseURL *url.URL, httpClient *http.Client, opts ...Option) *Clien
 		opt(client)
 	}
 
+	if client.httpClient == nil {
+		client.httpClient = &http.Client{
+			Timeout: client.timeout,
+		}
+	}
+
 	return client
 }
 
 // Do executes the given request and returns a raw *http.Response
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
-	return c.acquireLockAndSendWithRetries(req.Context(), req, 0, RequestOptions{})
+	return c.do(req, RequestOptions{})
 }
 
 // GET sends a GET request to the specified endpoint.
-// If you wish to receive logs from this method supply a logger inside the context using logr.NewContext.
 func (c *Client) GET(ctx context.Context, endpoint string, options RequestOptions) (*http.Response, error) {
 	return c.sendRequestWithRetries(ctx, http.MethodGet, endpoint, nil, options)
 }
 
 // PUT sends a PUT request to the specified endpoint with the given body.
-// If you wish to receive logs from this method supply a logger inside the context using logr.NewContext.
 func (c *Client) PUT(ctx context.Context, endpoint string, body io.Reader, options RequestOptions) (*http.Response, error) {
 	return c.sendRequestWithRetries(ctx, http.MethodPut, endpoint, body, options)
 }
 
 // POST sends a POST request to the specified endpoint with the given body.
-// If you wish to receive logs from this method supply a logger inside the context using logr.NewContext.
 func (c *Client) POST(ctx context.Context, endpoint string, body io.Reader, options RequestOptions) (*http.Response, error) {
 	return c.sendRequestWithRetries(ctx, http.MethodPost, endpoint, body, options)
 }
 
 // PATCH sends a PATCH request to the specified endpoint with the given body.
-// If you wish to receive logs from this method supply a logger inside the context using logr.NewContext.
 func (c *Client) PATCH(ctx context.Context, endpoint string, body io.Reader, options RequestOptions) (*http.Response, error) {
 	return c.sendRequestWithRetries(ctx, http.MethodPatch, endpoint, body, options)
 }
 
 // DELETE sends a DELETE request to the specified endpoint.
-// If you wish to receive logs from this method supply a logger inside the context using logr.NewContext.
 func (c *Client) DELETE(ctx context.Context, endpoint string, options RequestOptions) (*http.Response, error) {
 	return c.sendRequestWithRetries(ctx, http.MethodDelete, endpoint, nil, options)
 }
 
-// SetHeader sets a custom header for the HTTP client.
+// SetHeader sets a custom header for the HTTP client. It applies to all requests of the client and all API clients
+// sharing it; headers needed by single requests only should be passed via RequestOptions.Headers instead.
 func (c *Client) SetHeader(key, value string) {
 	c.headerMutex.Lock()
 	defer c.headerMutex.Unlock()
 	c.headers[key] = value
 }
 
+// ConcurrencyLimit returns the current maximum number of concurrent requests of the client, or 0 if the number is
+// not limited. For a client using WithAdaptiveConcurrency, the limit changes over time.
+func (c *Client) ConcurrencyLimit() int {
+	if c.concurrentRequestLimiter == nil {
+		return 0
+	}
+	return c.concurrentRequestLimiter.Limit()
+}
+
 // BaseURL returns the base url configured for this client
 func (c *Client) BaseURL() *url.URL {
 	return c.baseURL
 }
 
-func (c *Client) acquireLockAndSendWithRetries(ctx context.Context, req *http.Request, retryCount int, options RequestOptions) (*http.Response, error) {
-	// Apply concurrent request limiting if concurrentRequestLimiter is set
-	if c.concurrentRequestLimiter != nil {
-		c.concurrentRequestLimiter.Acquire()
-		defer c.concurrentRequestLimiter.Release()
+// do sends the request through the middleware chain of the client.
+func (c *Client) do(req *http.Request, options RequestOptions) (*http.Response, error) {
+	var err error
+	_, callerOwnsBody := asReusableReader(req.Body)
+	// wrap the body so that it could be read again, e.g. by an HTTPListener or for a retry
+	if req.Body, err = c.reusableBody(req.Body); err != nil {
+		return nil, err
+	}
+	if rr, ok := req.Body.(reusableReader); ok && !callerOwnsBody {
+		defer rr.release()
+	}
+
+	options.Idempotency = c.idempotencyFor(req, options)
+	ctx := withRequestOptions(req.Context(), options)
+	// middlewares log using the logger of the Client, or of the operation the request belongs to
+	ctx = withLogger(ctx, c.Logger(ctx))
+	ctx = withRedactor(ctx, c.Redactor())
+	if options.Timeout > 0 {
+		// all bodies are read before the chain returns, so the response stays readable after cancelling
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+	if c.metrics != nil {
+		ctx = withMetrics(ctx, c.metrics)
+	}
+	resp, err := c.chain()(req.WithContext(ctx))
+	streamResponse(resp)
+	if err != nil {
+		return resp, &requestError{err: err, redactor: c.Redactor()}
 	}
+	return resp, nil
+}
 
-	c.setHeadersOnRequest(req, options)
-	return c.sendWithRetries(ctx, req, retryCount, options)
+// headerMiddleware sets the Content-Type, the custom headers of the client and the headers of the RequestOptions on
+// each request.
+func (c *Client) headerMiddleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		c.setHeadersOnRequest(req, requestOptionsFrom(req))
+		return next(req)
+	}
 }
 
 func (c *Client) setHeadersOnRequest(req *http.Request, options RequestOptions) {
@@ -196,91 +292,56 @@ func (c *Client) setHeadersOnRequest(req *http.Request, options RequestOptions)
 
 	// set fixed headers
 	c.headerMutex.RLock()
-	defer c.headerMutex.RUnlock()
 	for key, value := range c.headers {
 		req.Header.Set(key, value)
 	}
-}
+	c.headerMutex.RUnlock()
 
-func (c *Client) sendWithRetries(ctx context.Context, req *http.Request, retryCount int, options RequestOptions) (*http.Response, error) {
-	if c.rateLimiter != nil {
-		c.rateLimiter.Wait(ctx) // If a limit is reached, this blocks until operations are permitted again
+	// set per-request headers
+	for key, values := range options.Headers {
+		req.Header[http.CanonicalHeaderKey(key)] = slices.Clone(values)
 	}
-
-	if c.httpClient == nil {
-		c.httpClient = &http.Client{
-			Timeout: c.timeout,
-		}
+	if options.Accept != "" {
+		req.Header.Set("Accept", options.Accept)
 	}
+}
 
-	var reqID string
+// transport is the innermost Handler of the middleware chain and sends the request exactly once.
+func (c *Client) transport(req *http.Request) (*http.Response, error) {
 	var err error
-	// wrap the body so that it could be read again
+	// rewind the body in case it was read before, e.g. by a previous attempt
 	if req.Body, err = ReusableReader(req.Body); err != nil {
 		return nil, err
 	}
-	if c.httpListener != nil {
-		reqID = uuid.NewString()
-		c.httpListener.onRequest(reqID, req)
+
+	parent := req.Context()
+	timeout := requestOptionsFrom(req).AttemptTimeout
+	if timeout > 0 {
+		// the response body is read completely before returning, so the attempt's context can be cancelled afterward
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
 	}
 
 	response, err := c.httpClient.Do(req)
+	if err == nil {
+		// wrap the body so that it could be read again
+		response.Body, err = c.reusableBody(response.Body)
+	}
 	if err != nil {
-		if c.httpListener != nil {
-			c.httpListener.onResponse(reqID, nil, err)
-		}
-
 		if isConnectionResetErr(err) {
 			return nil, fmt.Errorf("unable to connect to host %q, connection closed unexpectedly: %w", req.Host, err)
 		}
+		if timeout > 0 && parent.Err() == nil && errors.Is(req.Context().Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("attempt timed out after %s: %w", timeout, err)
+		}
 
 		return nil, err
 	}
 
-	// wrap the body so that it could be read again
-	if response.Body, err = ReusableReader(response.Body); err != nil {
-		return nil, err
-	}
-
-	if c.httpListener != nil {
-		c.httpListener.onResponse(reqID, response, nil)
-	}
-
-	// Update the rate limiter with the response headers
-	if c.rateLimiter != nil {
-		c.rateLimiter.Update(ctx, response.StatusCode, response.Header)
-	}
-
-	// merge client retry options with request retry options
-	retryOptions := mergeRetryOptions(c.retryOptions, options.CustomShouldRetryFunc, options.DelayAfterRetry, options.MaxRetries)
-
-	if ShouldRetry(response.StatusCode) && retryOptions.ShouldRetryFunc != nil && retryCount < retryOptions.MaxRetries && retryOptions.ShouldRetryFunc(response) {
-		slog.DebugContext(ctx, "Retrying failed request", slog.String("url", req.URL.String()), slog.Int("status", response.StatusCode), slog.Int64("delayMillis", retryOptions.DelayAfterRetry.Milliseconds()), slog.Int("retryCount", retryCount), slog.Int("maxRetryCount", retryOptions.MaxRetries))
-		time.Sleep(retryOptions.DelayAfterRetry)
-		return c.sendWithRetries(ctx, req, retryCount+1, options)
-	}
 	return response, nil
 }
 
-// mergeRetryOptions merges the client-set retry options with the options specified for a specific request.
-// The options for the request are preferred over the ones for the client
-func mergeRetryOptions(clientOptions *RetryOptions, retryFunc RetryFunc, delay *time.Duration, maxRetries *int) RetryOptions {
-	mergedOptions := RetryOptions{}
-	if clientOptions != nil {
-		mergedOptions = *clientOptions
-	}
-	if retryFunc != nil {
-		mergedOptions.ShouldRetryFunc = retryFunc
-	}
-	if delay != nil {
-		mergedOptions.DelayAfterRetry = *delay
-	}
-	if maxRetries != nil {
-		mergedOptions.MaxRetries = *maxRetries
-	}
-	return mergedOptions
-}
-
 // sendRequestWithRetries sends an HTTP request with custom headers and modified request body, with retries if configured.
 func (c *Client) sendRequestWithRetries(ctx context.Context, method string, endpoint string, body io.Reader, options RequestOptions) (*http.Response, error) {
 	fullURL := c.baseURL.JoinPath(endpoint)
@@ -293,7 +354,7 @@ func (c *Client) sendRequestWithRetries(ctx context.Context, method string, endp
 		return nil, err
 	}
 
-	return c.acquireLockAndSendWithRetries(ctx, req, 0, options)
+	return c.do(req, options)
 }
 
 func isConnectionResetErr(err error) bool {
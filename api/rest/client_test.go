@@ -23,6 +23,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -709,3 +710,213 @@ func TestClient_RequestOptionsCustomShouldRetryFunc(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 	assert.Equal(t, 2, apiHits)
 }
+
+func TestClient_WithRetries_CancelledContextAbortsWait(t *testing.T) {
+	apiHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		apiHits++
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, nil, WithRetryOptions(&RetryOptions{
+		MaxRetries:      10,
+		DelayAfterRetry: time.Hour,
+		ShouldRetryFunc: RetryIfNotSuccess,
+	}))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	startTime := time.Now()
+	resp, err := client.GET(ctx, "", RequestOptions{QueryParams: url.Values{"api-token": {"secret-query"}}})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotContains(t, err.Error(), "secret-query")
+	assert.Nil(t, resp)
+	assert.Less(t, time.Since(startTime), time.Second)
+	assert.Equal(t, 1, apiHits)
+}
+
+func TestClient_WithRetries_MaxElapsedTime(t *testing.T) {
+	apiHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		apiHits++
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, nil, WithRetryOptions(&RetryOptions{
+		MaxRetries:      10,
+		Backoff:         ExponentialBackoff{InitialDelay: 20 * time.Millisecond},
+		MaxElapsedTime:  100 * time.Millisecond,
+		ShouldRetryFunc: RetryIfNotSuccess,
+	}))
+
+	resp, err := client.GET(t.Context(), "", RequestOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 3, apiHits, "expected retries after 20ms and 40ms, but not after another 80ms")
+}
+
+func TestClient_WithRetries_HonorsRetryAfter(t *testing.T) {
+	apiHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		apiHits++
+		if apiHits == 1 {
+			rw.Header().Set("Retry-After", "3600")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, nil, WithRetryOptions(&RetryOptions{
+		MaxRetries:      1,
+		DelayAfterRetry: time.Hour,
+		MaxRetryAfter:   50 * time.Millisecond,
+		ShouldRetryFunc: RetryIfTooManyRequestsOrServiceUnavailable,
+	}))
+
+	startTime := time.Now()
+	resp, err := client.GET(t.Context(), "", RequestOptions{})
+	elapsedTime := time.Since(startTime)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, apiHits)
+	assert.GreaterOrEqual(t, elapsedTime, 50*time.Millisecond)
+	assert.Less(t, elapsedTime, time.Second)
+}
+
+func TestClient_WithRetries_RetriesTransientNetworkErrors(t *testing.T) {
+	apiHits := 0
+	server := httptest.NewUnstartedServer(nil)
+	server.Config.Handler = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		apiHits++
+		body, _ := io.ReadAll(req.Body)
+		assert.Equal(t, "payload", string(body))
+		if apiHits == 1 {
+			server.CloseClientConnections() // cause a connection reset on the first request
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	})
+	server.Start()
+	defer server.Close()
+
+	var records []RequestResponse
+	listener := &HTTPListener{Callback: func(rr RequestResponse) { records = append(records, rr) }}
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, server.Client(), WithHTTPListener(listener), WithRetryOptions(&RetryOptions{
+		MaxRetries:             1,
+		ShouldRetryOnErrorFunc: RetryIfTransientNetworkError,
+	}))
+
+	resp, err := client.POST(t.Context(), "", strings.NewReader("payload"), RequestOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, apiHits)
+
+	require.Len(t, records, 4, "expected request and response records for both attempts")
+	assert.Error(t, records[1].Error)
+	assert.NoError(t, records[3].Error)
+}
+
+func TestClient_WithRetries_NetworkErrorsAreNotRetriedByDefault(t *testing.T) {
+	records := 0
+	listener := &HTTPListener{Callback: func(rr RequestResponse) { records++ }}
+
+	client := NewClient(&url.URL{Scheme: "http", Host: "localhost"}, &http.Client{Transport: &errorTransport{}}, WithHTTPListener(listener), WithRetryOptions(&RetryOptions{
+		MaxRetries:      3,
+		ShouldRetryFunc: RetryIfNotSuccess,
+	}))
+
+	resp, err := client.GET(t.Context(), "", RequestOptions{})
+	assert.ErrorContains(t, err, "simulated network error")
+	assert.Nil(t, resp)
+	assert.Equal(t, 2, records, "expected only a single attempt")
+}
+
+func TestClient_RequestOptionsHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprintf(rw, "%s|%s|%s", req.Header.Get("X-Custom"), req.Header.Get("X-Client"), req.Header.Get("Accept"))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, nil)
+	client.SetHeader("X-Custom", "client")
+	client.SetHeader("X-Client", "client")
+
+	resp, err := client.GET(t.Context(), "", RequestOptions{
+		Headers: http.Header{"x-custom": {"request"}, "Accept": {"text/plain"}},
+		Accept:  "application/json",
+	})
+	require.NoError(t, err)
+	assertBody(t, "request|client|application/json", resp)
+
+	// per-request headers don't leak into other requests
+	resp, err = client.GET(t.Context(), "", RequestOptions{})
+	require.NoError(t, err)
+	assertBody(t, "client|client|", resp)
+}
+
+func TestClient_RequestOptionsTimeout(t *testing.T) {
+	apiHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		apiHits++
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, server.Client(), WithRetryOptions(&RetryOptions{
+		MaxRetries:      10,
+		DelayAfterRetry: 100 * time.Millisecond,
+		ShouldRetryFunc: RetryIfNotSuccess,
+	}))
+
+	start := time.Now()
+	_, err := client.GET(t.Context(), "", RequestOptions{Timeout: 150 * time.Millisecond})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 2, apiHits, "the overall timeout must include retries")
+}
+
+func TestClient_RequestOptionsAttemptTimeout(t *testing.T) {
+	apiHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		apiHits++
+		if apiHits == 1 {
+			<-req.Context().Done() // the first attempt hangs until the client gives up
+			return
+		}
+		_, _ = rw.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, server.Client(), WithRetryOptions(&RetryOptions{
+		MaxRetries:             1,
+		DelayAfterRetry:        time.Millisecond,
+		ShouldRetryOnErrorFunc: RetryIfTransientNetworkError,
+	}))
+
+	t.Run("timed out attempts are retried", func(t *testing.T) {
+		resp, err := client.GET(t.Context(), "", RequestOptions{AttemptTimeout: 50 * time.Millisecond})
+		require.NoError(t, err)
+		assertBody(t, "ok", resp)
+		assert.Equal(t, 2, apiHits)
+	})
+
+	t.Run("the error names the attempt timeout", func(t *testing.T) {
+		apiHits = 0
+		_, err := client.GET(t.Context(), "", RequestOptions{AttemptTimeout: 50 * time.Millisecond, MaxRetries: new(0)})
+		assert.ErrorContains(t, err, "attempt timed out after 50ms")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
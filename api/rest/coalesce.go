@@ -0,0 +1,177 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// WithRequestCoalescing collapses identical GET requests which are in flight at the same time into a single HTTP call.
+// Requests are identical if they have the same URL, including the query, and the same headers, including those given
+// in their RequestOptions.
+// Every caller receives an independent copy of the response, whose body can be read and re-read on its own.
+// The shared call is sent with the RequestOptions of the caller who issued it first.
+//
+// The shared call is only cancelled once the contexts of all waiting callers are done; a caller whose context is done
+// stops waiting and receives the context's error.
+func WithRequestCoalescing() Option {
+	return func(c *Client) {
+		c.coalescer = newCoalescer()
+	}
+}
+
+// coalescer tracks the in-flight calls of GET requests by their key.
+type coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+// coalescedCall is a call shared by all identical requests waiting for it.
+type coalescedCall struct {
+	done     chan struct{}
+	waiters  int
+	finished bool
+	cancel   context.CancelFunc
+
+	resp *http.Response
+	body reusableReader
+	err  error
+}
+
+func newCoalescer() *coalescer {
+	return &coalescer{calls: make(map[string]*coalescedCall)}
+}
+
+// CoalescingMiddleware returns a Middleware collapsing identical in-flight GET requests into a single call of the
+// next Handler. See WithRequestCoalescing.
+func CoalescingMiddleware() Middleware {
+	return newCoalescer().middleware
+}
+
+func (c *coalescer) middleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			return next(req)
+		}
+
+		key := coalescingKey(req)
+		c.mu.Lock()
+		call, inFlight := c.calls[key]
+		if !inFlight {
+			ctx, cancel := context.WithCancel(context.WithoutCancel(req.Context()))
+			call = &coalescedCall{done: make(chan struct{}), cancel: cancel}
+			c.calls[key] = call
+			go c.do(key, call, next, req.WithContext(ctx))
+		}
+		call.waiters++
+		c.mu.Unlock()
+
+		if inFlight {
+			addSpanAttributes(req.Context(), slog.Bool(AttributeCoalesced, true))
+		}
+
+		select {
+		case <-call.done:
+			resp, err := call.response(req)
+			c.leave(call)
+			return resp, err
+		case <-req.Context().Done():
+			c.leave(call)
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// do performs the shared call and publishes its result to all waiters.
+func (c *coalescer) do(key string, call *coalescedCall, next Handler, req *http.Request) {
+	defer call.cancel()
+
+	call.resp, call.err = next(req)
+	if call.err == nil {
+		var body io.ReadCloser
+		if body, call.err = ReusableReader(call.resp.Body); call.err == nil {
+			call.body, _ = asReusableReader(body)
+		}
+	}
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	call.finished = true
+	if call.waiters == 0 {
+		call.body.release()
+	}
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// leave removes a waiter from the call. Once the last waiter left, the call is cancelled if it is still in flight, or
+// its shared body is released otherwise.
+func (c *coalescer) leave(call *coalescedCall) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+	if call.finished {
+		call.body.release()
+	} else {
+		call.cancel()
+	}
+}
+
+// response returns an independent copy of the shared response for the given request.
+func (call *coalescedCall) response(req *http.Request) (*http.Response, error) {
+	if call.err != nil {
+		return call.resp, call.err
+	}
+
+	resp := *call.resp
+	resp.Header = call.resp.Header.Clone()
+	resp.Body = call.body.clone()
+	resp.Request = req
+	return &resp, nil
+}
+
+// coalescingKey identifies identical requests by their URL and headers, including the headers of their RequestOptions
+// which are only set later in the chain.
+func coalescingKey(req *http.Request) string {
+	header := req.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	options := requestOptionsFrom(req)
+	for name, values := range options.Headers {
+		header[http.CanonicalHeaderKey(name)] = values
+	}
+	if options.Accept != "" {
+		header.Set("Accept", options.Accept)
+	}
+
+	var b strings.Builder
+	b.WriteString(req.URL.String())
+	for _, name := range slices.Sorted(maps.Keys(header)) {
+		b.WriteString("\n" + name + ": " + strings.Join(header[name], ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,126 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithRequestCoalescing(t *testing.T) {
+	var apiHits atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		apiHits.Add(1)
+		<-release
+		_, _ = rw.Write([]byte(`{"id":"` + req.URL.Path + `"}`))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+
+	client := NewClient(baseURL, nil, WithRequestCoalescing())
+
+	const callers = 5
+	var wg sync.WaitGroup
+	bodies := make([]string, callers)
+	for i := range callers {
+		wg.Go(func() {
+			resp, err := client.GET(t.Context(), "/a", RequestOptions{})
+			if !assert.NoError(t, err) {
+				return
+			}
+			// each caller can read the body twice, independently of all others
+			first, _ := io.ReadAll(resp.Body)
+			second, _ := io.ReadAll(resp.Body)
+			assert.Equal(t, first, second)
+			bodies[i] = string(first)
+		})
+	}
+
+	assert.Eventually(t, func() bool { return client.coalescer.waitersFor("/a") == callers }, time.Second, time.Millisecond)
+
+	// different URLs are not coalesced
+	wg.Go(func() {
+		_, err := client.GET(t.Context(), "/b", RequestOptions{})
+		assert.NoError(t, err)
+	})
+	assert.Eventually(t, func() bool { return apiHits.Load() == 2 }, time.Second, time.Millisecond)
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(2), apiHits.Load())
+	for _, b := range bodies {
+		assert.Equal(t, `{"id":"/a"}`, b)
+	}
+}
+
+func TestCoalescingMiddleware_Cancellation(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan context.Context, 1)
+	c := newCoalescer()
+	handler := c.middleware(func(req *http.Request) (*http.Response, error) {
+		started <- req.Context()
+		<-release
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, req.Context().Err()
+	})
+
+	leaderCtx, cancelLeader := context.WithCancel(t.Context())
+	leaderDone := make(chan error)
+	go func() {
+		_, err := handler(httptest.NewRequestWithContext(leaderCtx, http.MethodGet, "https://example.com/a", nil))
+		leaderDone <- err
+	}()
+	sharedCtx := <-started
+
+	followerDone := make(chan error)
+	go func() {
+		_, err := handler(httptest.NewRequestWithContext(t.Context(), http.MethodGet, "https://example.com/a", nil))
+		followerDone <- err
+	}()
+	require.Eventually(t, func() bool { return c.waitersFor("/a") == 2 }, time.Second, time.Millisecond)
+
+	// the leader gives up, but the shared call continues for the follower
+	cancelLeader()
+	assert.ErrorIs(t, <-leaderDone, context.Canceled)
+	assert.NoError(t, sharedCtx.Err())
+
+	close(release)
+	assert.NoError(t, <-followerDone)
+}
+
+// waitersFor returns the number of callers waiting for the in-flight call of the given path.
+func (c *coalescer) waitersFor(path string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, call := range c.calls {
+		u, _ := url.Parse(strings.SplitN(key, "\n", 2)[0])
+		if u.Path == path {
+			return call.waiters
+		}
+	}
+	return 0
+}
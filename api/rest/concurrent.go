@@ -14,9 +14,23 @@
 
 package rest
 
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+const defaultDecreaseFactor = 0.5
+
 // ConcurrentRequestLimiter represents a component for limiting concurrent requests.
+// It either enforces a fixed limit, see NewConcurrentRequestLimiter, or adapts its limit to the responses of the
+// server, see NewAdaptiveConcurrentRequestLimiter.
 type ConcurrentRequestLimiter struct {
-	sem chan struct{}
+	sem      chan struct{}
+	adaptive *adaptiveLimiter
 }
 
 // NewConcurrentRequestLimiter creates a new instance of ConcurrentRequestLimiter with the specified limit to apply.
@@ -32,15 +46,82 @@ func NewConcurrentRequestLimiter(maxConcurrent int) *ConcurrentRequestLimiter {
 	}
 }
 
+// AdaptiveConcurrencyOptions configure a ConcurrentRequestLimiter adapting its limit using the AIMD (additive
+// increase, multiplicative decrease) algorithm: while responses are healthy, the limit grows by one each time as many
+// requests as the current limit have succeeded. On a 429 Too Many Requests or 503 Service Unavailable response, or a
+// response slower than LatencyThreshold, the limit is multiplied by DecreaseFactor.
+type AdaptiveConcurrencyOptions struct {
+	// MinLimit is the lowest the limit can drop to. Defaults to 1.
+	MinLimit int
+	// MaxLimit is the highest the limit can grow to. Defaults to MinLimit.
+	MaxLimit int
+	// InitialLimit is the limit to start with. Defaults to MinLimit.
+	InitialLimit int
+	// DecreaseFactor is multiplied with the limit if the server signals overload. Defaults to 0.5.
+	DecreaseFactor float64
+	// LatencyThreshold optionally treats responses taking longer than this as a signal of overload.
+	LatencyThreshold time.Duration
+}
+
+// NewAdaptiveConcurrentRequestLimiter creates a new ConcurrentRequestLimiter adapting its limit to the responses of
+// the server within the bounds given by the AdaptiveConcurrencyOptions.
+func NewAdaptiveConcurrentRequestLimiter(opts AdaptiveConcurrencyOptions) *ConcurrentRequestLimiter {
+	opts.MinLimit = max(opts.MinLimit, 1)
+	opts.MaxLimit = max(opts.MaxLimit, opts.MinLimit)
+	if opts.InitialLimit == 0 {
+		opts.InitialLimit = opts.MinLimit
+	}
+	if opts.DecreaseFactor <= 0 || opts.DecreaseFactor >= 1 {
+		opts.DecreaseFactor = defaultDecreaseFactor
+	}
+
+	return &ConcurrentRequestLimiter{
+		adaptive: &adaptiveLimiter{
+			opts:  opts,
+			limit: min(max(opts.InitialLimit, opts.MinLimit), opts.MaxLimit),
+		},
+	}
+}
+
+// WithAdaptiveConcurrency limits the number of concurrent requests using an adaptive ConcurrentRequestLimiter.
+// See AdaptiveConcurrencyOptions for details.
+func WithAdaptiveConcurrency(opts AdaptiveConcurrencyOptions) Option {
+	return func(c *Client) {
+		c.concurrentRequestLimiter = NewAdaptiveConcurrentRequestLimiter(opts)
+	}
+}
+
 // Acquire acquires a slot from the concurrent request limiter to check for maximum concurrent requests.
-func (c *ConcurrentRequestLimiter) Acquire() {
-	if c.sem != nil {
-		c.sem <- struct{}{}
+// It blocks until a slot is available or ctx is done, in which case the context's error is returned and no slot is
+// held.
+func (c *ConcurrentRequestLimiter) Acquire(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if c.adaptive != nil {
+		return c.adaptive.acquire(ctx)
+	}
+
+	if c.sem == nil {
+		return nil
+	}
+
+	select {
+	case c.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
 // Release releases a slot from the concurrent request limiter to allow subsequent requests to proceed.
 func (c *ConcurrentRequestLimiter) Release() {
+	if c.adaptive != nil {
+		c.adaptive.release()
+		return
+	}
+
 	if c.sem != nil {
 		select {
 		case <-c.sem:
@@ -49,3 +130,163 @@ func (c *ConcurrentRequestLimiter) Release() {
 		}
 	}
 }
+
+// Limit returns the current maximum number of concurrent requests, or 0 if the number is not limited.
+func (c *ConcurrentRequestLimiter) Limit() int {
+	if c.adaptive != nil {
+		return c.adaptive.currentLimit()
+	}
+	return cap(c.sem)
+}
+
+// Observe feeds the outcome of a request started at the given time back into an adaptive limiter.
+// resp is nil if no response was received. For a limiter with a fixed limit, this is a no-op.
+func (c *ConcurrentRequestLimiter) Observe(started time.Time, resp *http.Response) {
+	if c.adaptive != nil && resp != nil {
+		c.adaptive.observe(started, resp.StatusCode, time.Since(started))
+	}
+}
+
+// ConcurrencyLimitMiddleware returns a Middleware which holds a slot of the given ConcurrentRequestLimiter
+// while the request is being processed by the rest of the chain.
+// Every attempt to send the request is observed by the limiter, allowing an adaptive limiter to react to it.
+func ConcurrencyLimitMiddleware(limiter *ConcurrentRequestLimiter) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			err := limiter.Acquire(req.Context())
+			if collector := metricsFrom(req); collector != nil {
+				collector.ObserveConcurrencyLimitWait(metricLabels(req), time.Since(start))
+			}
+			if err != nil {
+				return nil, fmt.Errorf("waiting for concurrent request limiter aborted: %w", err)
+			}
+			defer limiter.Release()
+
+			if limiter.adaptive != nil {
+				req = req.WithContext(withConcurrencyLimiter(req.Context(), limiter))
+			}
+			return next(req)
+		}
+	}
+}
+
+// concurrencyFeedbackMiddleware reports each attempt to the ConcurrentRequestLimiter of the call, if any.
+// It is the innermost middleware of the chain, so that the observed latency is the one of the server only.
+func concurrencyFeedbackMiddleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		limiter := concurrencyLimiterFrom(req.Context())
+		if limiter == nil {
+			return next(req)
+		}
+
+		start := time.Now()
+		resp, err := next(req)
+		limiter.Observe(start, resp)
+		return resp, err
+	}
+}
+
+type concurrencyLimiterKey struct{}
+
+func withConcurrencyLimiter(ctx context.Context, limiter *ConcurrentRequestLimiter) context.Context {
+	return context.WithValue(ctx, concurrencyLimiterKey{}, limiter)
+}
+
+func concurrencyLimiterFrom(ctx context.Context) *ConcurrentRequestLimiter {
+	limiter, _ := ctx.Value(concurrencyLimiterKey{}).(*ConcurrentRequestLimiter)
+	return limiter
+}
+
+// adaptiveLimiter is a semaphore whose limit is adjusted using AIMD.
+type adaptiveLimiter struct {
+	opts AdaptiveConcurrencyOptions
+
+	mu           sync.Mutex
+	limit        int
+	inFlight     int
+	successes    int             // successful requests since the limit was last changed
+	lastDecrease time.Time       // requests started before this are not considered for further decreases
+	waiters      []chan struct{} // acquirers waiting for a slot, in FIFO order
+}
+
+func (l *adaptiveLimiter) acquire(ctx context.Context) error {
+	l.mu.Lock()
+	if l.inFlight < l.limit && len(l.waiters) == 0 {
+		l.inFlight++
+		l.mu.Unlock()
+		return nil
+	}
+
+	ready := make(chan struct{})
+	l.waiters = append(l.waiters, ready)
+	l.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if i := slices.Index(l.waiters, ready); i >= 0 {
+		l.waiters = slices.Delete(l.waiters, i, i+1)
+	} else {
+		// the slot was handed over concurrently with the cancellation, pass it on
+		l.inFlight--
+		l.wakeWaiters()
+	}
+	return ctx.Err()
+}
+
+func (l *adaptiveLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inFlight > 0 {
+		l.inFlight--
+	}
+	l.wakeWaiters()
+}
+
+// wakeWaiters hands free slots to waiting acquirers. l.mu must be held.
+func (l *adaptiveLimiter) wakeWaiters() {
+	for l.inFlight < l.limit && len(l.waiters) > 0 {
+		close(l.waiters[0])
+		l.waiters = l.waiters[1:]
+		l.inFlight++
+	}
+}
+
+func (l *adaptiveLimiter) currentLimit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+func (l *adaptiveLimiter) observe(started time.Time, status int, latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	overloaded := status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable ||
+		(l.opts.LatencyThreshold > 0 && latency > l.opts.LatencyThreshold)
+
+	if overloaded {
+		// requests started before the last decrease were sent with the old limit and must not reduce it once more
+		if started.Before(l.lastDecrease) {
+			return
+		}
+		l.limit = max(int(float64(l.limit)*l.opts.DecreaseFactor), l.opts.MinLimit)
+		l.successes = 0
+		l.lastDecrease = time.Now()
+		return
+	}
+
+	l.successes++
+	if l.successes >= l.limit && l.limit < l.opts.MaxLimit {
+		l.limit++
+		l.successes = 0
+		l.wakeWaiters()
+	}
+}
@@ -15,9 +15,17 @@
 package rest
 
 import (
-	"github.com/stretchr/testify/assert"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"sync"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConcurrentRequestLimiter_AcquireAndRelease(t *testing.T) {
@@ -26,17 +34,17 @@ func TestConcurrentRequestLimiter_AcquireAndRelease(t *testing.T) {
 	wg.Add(2)
 
 	go func() {
-		limiter.Acquire()
+		limiter.Acquire(t.Context())
 		wg.Done()
 	}()
 	go func() {
-		limiter.Acquire()
+		limiter.Acquire(t.Context())
 		wg.Done()
 	}()
 	wg.Wait()
 	wg.Add(2)
 	go func() {
-		limiter.Acquire()
+		limiter.Acquire(t.Context())
 		wg.Done()
 	}()
 	go func() {
@@ -48,13 +56,159 @@ func TestConcurrentRequestLimiter_AcquireAndRelease(t *testing.T) {
 
 func TestConcurrentRequestLimiter_AcquireWithoutLimit(t *testing.T) {
 	limiter := NewConcurrentRequestLimiter(0)
-	limiter.Acquire()
-	limiter.Acquire()
-	limiter.Acquire()
+	limiter.Acquire(t.Context())
+	limiter.Acquire(t.Context())
+	limiter.Acquire(t.Context())
 	assert.Nil(t, limiter.sem, "Semaphore should be nil when maxConcurrent is 0")
 	limiter = NewConcurrentRequestLimiter(-1)
-	limiter.Acquire()
-	limiter.Acquire()
-	limiter.Acquire()
+	limiter.Acquire(t.Context())
+	limiter.Acquire(t.Context())
+	limiter.Acquire(t.Context())
 	assert.Nil(t, limiter.sem, "Semaphore should be nil when maxConcurrent is -1")
 }
+
+func TestAdaptiveConcurrentRequestLimiter_Bounds(t *testing.T) {
+	limiter := NewAdaptiveConcurrentRequestLimiter(AdaptiveConcurrencyOptions{MinLimit: 2, MaxLimit: 4, InitialLimit: 10})
+	assert.Equal(t, 4, limiter.Limit(), "initial limit should be capped at MaxLimit")
+
+	limiter = NewAdaptiveConcurrentRequestLimiter(AdaptiveConcurrencyOptions{})
+	assert.Equal(t, 1, limiter.Limit(), "limit should default to 1")
+}
+
+func TestAdaptiveConcurrentRequestLimiter_IncreasesAdditively(t *testing.T) {
+	limiter := NewAdaptiveConcurrentRequestLimiter(AdaptiveConcurrencyOptions{MinLimit: 1, MaxLimit: 3})
+	ok := &http.Response{StatusCode: http.StatusOK}
+
+	limiter.Observe(time.Now(), ok)
+	assert.Equal(t, 2, limiter.Limit())
+
+	limiter.Observe(time.Now(), ok)
+	assert.Equal(t, 2, limiter.Limit(), "limit should only grow after as many successes as the current limit")
+	limiter.Observe(time.Now(), ok)
+	assert.Equal(t, 3, limiter.Limit())
+
+	for range 10 {
+		limiter.Observe(time.Now(), ok)
+	}
+	assert.Equal(t, 3, limiter.Limit(), "limit should not exceed MaxLimit")
+}
+
+func TestAdaptiveConcurrentRequestLimiter_DecreasesMultiplicatively(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		t.Run(strconv.Itoa(status), func(t *testing.T) {
+			limiter := NewAdaptiveConcurrentRequestLimiter(AdaptiveConcurrencyOptions{MinLimit: 2, MaxLimit: 16, InitialLimit: 16})
+
+			limiter.Observe(time.Now(), &http.Response{StatusCode: status})
+			assert.Equal(t, 8, limiter.Limit())
+
+			limiter.Observe(time.Now(), &http.Response{StatusCode: status})
+			assert.Equal(t, 4, limiter.Limit())
+
+			limiter.Observe(time.Now(), &http.Response{StatusCode: status})
+			limiter.Observe(time.Now(), &http.Response{StatusCode: status})
+			assert.Equal(t, 2, limiter.Limit(), "limit should not drop below MinLimit")
+		})
+	}
+
+	t.Run("requests started before a decrease do not decrease again", func(t *testing.T) {
+		limiter := NewAdaptiveConcurrentRequestLimiter(AdaptiveConcurrencyOptions{MaxLimit: 16, InitialLimit: 16})
+		started := time.Now()
+		limiter.Observe(started, &http.Response{StatusCode: http.StatusTooManyRequests})
+		limiter.Observe(started, &http.Response{StatusCode: http.StatusTooManyRequests})
+		assert.Equal(t, 8, limiter.Limit())
+	})
+
+	t.Run("slow responses decrease the limit", func(t *testing.T) {
+		limiter := NewAdaptiveConcurrentRequestLimiter(AdaptiveConcurrencyOptions{MaxLimit: 16, InitialLimit: 16, LatencyThreshold: time.Second})
+		limiter.Observe(time.Now().Add(-2*time.Second), &http.Response{StatusCode: http.StatusOK})
+		assert.Equal(t, 8, limiter.Limit())
+	})
+}
+
+func TestAdaptiveConcurrentRequestLimiter_EnforcesLimit(t *testing.T) {
+	limiter := NewAdaptiveConcurrentRequestLimiter(AdaptiveConcurrencyOptions{MinLimit: 1, MaxLimit: 2})
+	limiter.Acquire(t.Context())
+
+	acquired := make(chan struct{})
+	go func() {
+		limiter.Acquire(t.Context())
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("expected Acquire to block while the limit is reached")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	limiter.Observe(time.Now(), &http.Response{StatusCode: http.StatusOK}) // raises limit to 2, admitting the waiter
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("expected waiter to acquire a slot after the limit was raised")
+	}
+}
+
+func TestClient_WithAdaptiveConcurrency(t *testing.T) {
+	apiHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		apiHits++
+		if apiHits == 1 {
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, nil,
+		WithAdaptiveConcurrency(AdaptiveConcurrencyOptions{MinLimit: 1, MaxLimit: 10, InitialLimit: 8}),
+		WithRetryOptions(&RetryOptions{MaxRetries: 1, ShouldRetryFunc: RetryIfTooManyRequestsOrServiceUnavailable}),
+	)
+	assert.Equal(t, 8, client.ConcurrencyLimit())
+
+	resp, err := client.GET(t.Context(), "", RequestOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 4, client.ConcurrencyLimit(), "expected limit to be cut by the 429 of the first attempt")
+}
+
+func TestConcurrentRequestLimiter_AcquireRespectsContext(t *testing.T) {
+	limiters := map[string]*ConcurrentRequestLimiter{
+		"fixed":    NewConcurrentRequestLimiter(1),
+		"adaptive": NewAdaptiveConcurrentRequestLimiter(AdaptiveConcurrencyOptions{MaxLimit: 1}),
+	}
+
+	for name, limiter := range limiters {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, limiter.Acquire(t.Context()))
+
+			ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+			defer cancel()
+			err := limiter.Acquire(ctx)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+			limiter.Release()
+			acquireCtx, cancelAcquire := context.WithTimeout(t.Context(), time.Second)
+			defer cancelAcquire()
+			assert.NoError(t, limiter.Acquire(acquireCtx), "expected aborted Acquire not to hold a slot")
+		})
+	}
+}
+
+func TestClient_ConcurrencyLimit_CancelledWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, nil, WithConcurrentRequestLimit(1))
+	require.NoError(t, client.concurrentRequestLimiter.Acquire(t.Context())) // occupy the only slot
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.GET(ctx, "", RequestOptions{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
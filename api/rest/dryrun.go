@@ -0,0 +1,220 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
+)
+
+// PlannedRequest is a mutating request which was intercepted in dry-run mode instead of being sent.
+type PlannedRequest struct {
+	Time   time.Time
+	Method string
+	// URL is the full URL the request would have been sent to.
+	URL string
+	// Path is the path of the request relative to the base URL of the Client.
+	Path string
+	// Endpoint is the RequestOptions.Endpoint of the request, if set.
+	Endpoint string
+	Body     []byte
+}
+
+// DryRunResponse is the response synthesized for a PlannedRequest.
+type DryRunResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// DryRunResponder synthesizes the response of a PlannedRequest.
+type DryRunResponder func(req PlannedRequest) DryRunResponse
+
+// DryRunOptions configure the dry-run mode of a Client. See WithDryRun.
+type DryRunOptions struct {
+	// Plan records the intercepted requests. If not set, a new DryRunPlan is created, see Client.DryRunPlan.
+	// Clients sharing a DryRunPlan record their requests in the same plan.
+	Plan *DryRunPlan
+
+	// Responders optionally synthesize the responses of specific resources, keyed by path prefix relative to the base
+	// URL of the Client, e.g. "platform/document/v1/documents". The responder of the longest matching prefix is used.
+	// Requests without a matching responder are answered by DefaultDryRunResponse.
+	Responders map[string]DryRunResponder
+}
+
+// WithDryRun enables the dry-run mode of the Client, e.g. to preview what a deployment would change.
+//
+// GET requests are sent as usual, but POST, PUT, PATCH and DELETE requests are not sent. Instead, they are recorded in
+// the DryRunPlan and answered with a synthesized success response, so that logic consisting of several requests, like
+// creating an object and updating it afterward, keeps working. Synthesized responses can be configured per resource
+// via DryRunOptions.Responders.
+func WithDryRun(opts DryRunOptions) Option {
+	if opts.Plan == nil {
+		opts.Plan = NewDryRunPlan()
+	}
+	return func(c *Client) {
+		c.dryRun = &opts
+	}
+}
+
+// DryRunPlan returns the plan recording the requests intercepted in dry-run mode, or nil if the dry-run mode is not
+// enabled.
+func (c *Client) DryRunPlan() *DryRunPlan {
+	if c.dryRun == nil {
+		return nil
+	}
+	return c.dryRun.Plan
+}
+
+// DryRunPlan is the log of the requests intercepted in dry-run mode. It is safe for concurrent use.
+type DryRunPlan struct {
+	mu        sync.Mutex
+	requests  []PlannedRequest
+	redactors []*redact.Redactor // redactors of the Clients which made the requests
+}
+
+// NewDryRunPlan creates a new, empty DryRunPlan.
+func NewDryRunPlan() *DryRunPlan {
+	return &DryRunPlan{}
+}
+
+// Requests returns the intercepted requests in the order they were made.
+func (p *DryRunPlan) Requests() []PlannedRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.requests)
+}
+
+// String returns the plan as human-readable log, with one line per request and secrets redacted using the Redactor of
+// the Client which made the request, see WithRedactor.
+func (p *DryRunPlan) String() string {
+	p.mu.Lock()
+	requests, redactors := slices.Clone(p.requests), slices.Clone(p.redactors)
+	p.mu.Unlock()
+
+	var b strings.Builder
+	for i, r := range requests {
+		redactor := redactors[i]
+		fmt.Fprintf(&b, "%s %s", r.Method, redactor.URLString(r.URL))
+		if len(r.Body) > 0 {
+			fmt.Fprintf(&b, " %s", redactor.Body(r.Body))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (p *DryRunPlan) add(r PlannedRequest, redactor *redact.Redactor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = append(p.requests, r)
+	p.redactors = append(p.redactors, redactor)
+}
+
+// DefaultDryRunResponse synthesizes a successful response for the given PlannedRequest:
+//   - POST requests are answered with 201 Created, echoing a JSON object payload. If the payload has no "id" field, a
+//     generated one is added, so that callers can refer to the created object.
+//   - PUT and PATCH requests are answered with 200 OK, echoing a JSON object payload, or an empty JSON object otherwise.
+//   - DELETE requests are answered with 204 No Content.
+func DefaultDryRunResponse(req PlannedRequest) DryRunResponse {
+	if req.Method == http.MethodDelete {
+		return DryRunResponse{StatusCode: http.StatusNoContent, Header: http.Header{}}
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal(req.Body, &object); err != nil || object == nil {
+		object = map[string]any{}
+	}
+
+	status := http.StatusOK
+	if req.Method == http.MethodPost {
+		status = http.StatusCreated
+		if _, ok := object["id"]; !ok {
+			object["id"] = "dry-run-" + uuid.NewString()
+		}
+	}
+
+	body, _ := json.Marshal(object) // an object decoded from JSON can always be marshaled
+	return DryRunResponse{StatusCode: status, Header: http.Header{"Content-Type": {"application/json"}}, Body: body}
+}
+
+// dryRunMiddleware records mutating requests in the DryRunPlan and answers them with synthesized responses.
+func (c *Client) dryRunMiddleware(next Handler) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodOptions {
+			return next(req)
+		}
+
+		// reading the reusable body to its end rewinds it
+		body, err := readBody(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+
+		planned := PlannedRequest{
+			Time:     time.Now(),
+			Method:   req.Method,
+			URL:      req.URL.String(),
+			Path:     strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(c.baseURL.Path, "/")), "/"),
+			Endpoint: requestOptionsFrom(req).Endpoint,
+			Body:     bytes.Clone(body),
+		}
+		redactor := RedactorFromContext(req.Context())
+		c.dryRun.Plan.add(planned, redactor)
+		LoggerFromContext(req.Context()).InfoContext(req.Context(), "Dry run: request not sent", slog.String("method", req.Method), slog.String("url", redactor.URL(req.URL).String()))
+
+		synthesized := c.dryRun.responderFor(planned.Path)(planned)
+		if synthesized.Header == nil {
+			synthesized.Header = http.Header{}
+		}
+		synthesized.Header.Set("Content-Length", strconv.Itoa(len(synthesized.Body)))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", synthesized.StatusCode, http.StatusText(synthesized.StatusCode)),
+			StatusCode:    synthesized.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        synthesized.Header,
+			Body:          newReusableReader(synthesized.Body),
+			ContentLength: int64(len(synthesized.Body)),
+			Request:       req,
+		}, nil
+	}
+}
+
+// responderFor returns the responder of the longest prefix matching the given path, considering whole path segments only.
+func (o *DryRunOptions) responderFor(path string) DryRunResponder {
+	responder, longest := DryRunResponder(DefaultDryRunResponse), -1
+	for prefix, r := range o.Responders {
+		trimmed := strings.Trim(prefix, "/")
+		matches := trimmed == "" || path == trimmed || strings.HasPrefix(path, trimmed+"/")
+		if matches && len(trimmed) > longest {
+			responder, longest = r, len(trimmed)
+		}
+	}
+	return responder
+}
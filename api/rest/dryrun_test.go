@@ -0,0 +1,110 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithDryRun(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		sent = append(sent, req.Method+" "+req.URL.Path)
+		_, _ = rw.Write([]byte(`{"id":"existing"}`))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL + "/base")
+
+	client := NewClient(baseURL, server.Client(), WithDryRun(DryRunOptions{
+		Responders: map[string]DryRunResponder{
+			"/objects/special": func(req PlannedRequest) DryRunResponse {
+				return DryRunResponse{StatusCode: http.StatusAccepted, Body: []byte(`"special"`)}
+			},
+		},
+	}))
+
+	t.Run("GET requests are sent", func(t *testing.T) {
+		resp, err := client.GET(t.Context(), "objects/a", RequestOptions{})
+		require.NoError(t, err)
+		assertBody(t, `{"id":"existing"}`, resp)
+		assert.Equal(t, []string{"GET /base/objects/a"}, sent)
+	})
+
+	t.Run("POST requests are answered with the payload and a generated id", func(t *testing.T) {
+		resp, err := client.POST(t.Context(), "objects", strings.NewReader(`{"name":"new"}`), RequestOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var created map[string]string
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+		assert.Equal(t, "new", created["name"])
+		assert.True(t, strings.HasPrefix(created["id"], "dry-run-"))
+	})
+
+	t.Run("PUT and DELETE requests are answered with success", func(t *testing.T) {
+		resp, err := client.PUT(t.Context(), "objects/a", strings.NewReader(`not JSON`), RequestOptions{Endpoint: "/objects/{id}"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assertBody(t, `{}`, resp)
+
+		resp, err = client.DELETE(t.Context(), "objects/a", RequestOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("responders are matched by path prefix", func(t *testing.T) {
+		resp, err := client.PATCH(t.Context(), "objects/special/1", nil, RequestOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		assertBody(t, `"special"`, resp)
+
+		resp, err = client.PATCH(t.Context(), "objects/specialist", nil, RequestOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	assert.Equal(t, []string{"GET /base/objects/a"}, sent, "mutating requests must not be sent")
+
+	plan := client.DryRunPlan().Requests()
+	require.Len(t, plan, 5)
+	assert.Equal(t, PlannedRequest{Time: plan[0].Time, Method: http.MethodPost, URL: server.URL + "/base/objects", Path: "objects", Body: []byte(`{"name":"new"}`)}, plan[0])
+	assert.Equal(t, "/objects/{id}", plan[1].Endpoint)
+	assert.Equal(t, "objects/a", plan[2].Path)
+	assert.Equal(t, "POST "+server.URL+`/base/objects {"name":"new"}`, strings.SplitN(client.DryRunPlan().String(), "\n", 2)[0])
+
+	t.Run("plan is redacted using the redactor of each client", func(t *testing.T) {
+		plan := NewDryRunPlan()
+		redacting := NewClient(baseURL, nil, WithDryRun(DryRunOptions{Plan: plan}), WithRedactor(redact.Default().With(redact.Options{Secrets: []string{"client-s3cr3t"}})))
+		other := NewClient(baseURL, nil, WithDryRun(DryRunOptions{Plan: plan}))
+
+		_, err := redacting.POST(t.Context(), "objects", strings.NewReader(`{"name":"client-s3cr3t"}`), RequestOptions{})
+		require.NoError(t, err)
+		_, err = other.POST(t.Context(), "objects", strings.NewReader(`{"name":"client-s3cr3t"}`), RequestOptions{})
+		require.NoError(t, err)
+
+		lines := strings.Split(plan.String(), "\n")
+		assert.Contains(t, lines[0], `{"name":"REDACTED"}`)
+		assert.Contains(t, lines[1], `{"name":"client-s3cr3t"}`, "secrets of one client must not affect others")
+	})
+}
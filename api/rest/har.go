@@ -0,0 +1,318 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
+)
+
+const (
+	harVersion     = "1.2"
+	harCreatorName = "dynatrace-configuration-as-code-core"
+	harCreatorPath = "github.com/dynatrace/dynatrace-configuration-as-code-core"
+)
+
+// harCreatorVersion returns the version of this module as recorded in the build info of the binary, or "(devel)" if
+// it is unknown, e.g. in tests.
+var harCreatorVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+	if info.Main.Path == harCreatorPath && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == harCreatorPath {
+			if dep.Replace != nil && dep.Replace.Version != "" {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return "(devel)"
+})
+
+// HAR is the root object of an HTTP Archive (HAR 1.2) file.
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog is the log object of an HTTP Archive.
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator names the application which created an HTTP Archive.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is a single request/response exchange of an HTTP Archive.
+type HAREntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"` // total time in milliseconds
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	Error           string      `json:"_error,omitempty"` // error of requests which failed without response
+}
+
+// HARRequest is the request of a HAREntry.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARResponse is the response of a HAREntry. For requests which failed without response, Status is 0.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// HARNameValue is a name/value pair, e.g. a header or query parameter.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData is the body of a HARRequest.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent is the body of a HARResponse.
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+// HARTimings are the timings of a HAREntry in milliseconds. As only the total time of an exchange is known, it is
+// reported as Wait.
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// HARRecorderOptions configure a HARRecorder.
+type HARRecorderOptions struct {
+	// RedactHeaders are additional headers whose values are redacted.
+	// Headers matched by the default redact.Redactor are always redacted.
+	RedactHeaders []string
+	// RedactQueryParams are additional query parameters whose values are redacted.
+	RedactQueryParams []string
+	// Unredacted disables all redaction. Only use it if the resulting archive is not shared.
+	Unredacted bool
+}
+
+// HARRecorder assembles the requests and responses observed by its HTTPListener into complete exchanges, which can
+// be written as HTTP Archive (HAR 1.2), e.g. to analyze the traffic of a failing deployment using standard tooling.
+// Sensitive headers, query parameters and body fields are redacted by default.
+type HARRecorder struct {
+	opts HARRecorderOptions
+
+	mu      sync.Mutex
+	pending map[string]*HAREntry
+	entries []*HAREntry
+}
+
+// NewHARRecorder creates a new HARRecorder.
+func NewHARRecorder(opts HARRecorderOptions) *HARRecorder {
+	return &HARRecorder{
+		opts:    opts,
+		pending: make(map[string]*HAREntry),
+	}
+}
+
+// Listener returns the HTTPListener feeding the HARRecorder. Pass it to WithHTTPListener.
+func (h *HARRecorder) Listener() *HTTPListener {
+	if h.opts.Unredacted {
+		return &HTTPListener{Callback: h.observe, Unredacted: true}
+	}
+	return &HTTPListener{Callback: h.observe, Redactor: redact.Default().With(redact.Options{
+		Headers:     redact.Names(h.opts.RedactHeaders...),
+		QueryParams: redact.Names(h.opts.RedactQueryParams...),
+	})}
+}
+
+func (h *HARRecorder) observe(rr RequestResponse) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if req, ok := rr.IsRequest(); ok {
+		entry := &HAREntry{StartedDateTime: rr.Timestamp, Request: h.harRequest(req)}
+		h.pending[rr.ID] = entry
+		h.entries = append(h.entries, entry)
+		return
+	}
+
+	entry, ok := h.pending[rr.ID]
+	if !ok {
+		return
+	}
+	delete(h.pending, rr.ID)
+
+	elapsed := float64(rr.Timestamp.Sub(entry.StartedDateTime).Microseconds()) / 1000
+	entry.Time = elapsed
+	entry.Timings = HARTimings{Wait: elapsed}
+
+	if resp, ok := rr.IsResponse(); ok {
+		entry.Response = h.harResponse(resp)
+	} else {
+		entry.Response = HARResponse{Cookies: []HARNameValue{}, Headers: []HARNameValue{}, HeadersSize: -1, BodySize: -1}
+	}
+	if rr.Error != nil {
+		entry.Error = rr.Error.Error()
+	}
+}
+
+// HAR returns all exchanges recorded so far as HTTP Archive. Exchanges still waiting for a response are included
+// without response.
+func (h *HARRecorder) HAR() HAR {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entries := make([]HAREntry, len(h.entries))
+	for i, e := range h.entries {
+		entries[i] = *e
+	}
+	return HAR{Log: HARLog{
+		Version: harVersion,
+		Creator: HARCreator{Name: harCreatorName, Version: harCreatorVersion()},
+		Entries: entries,
+	}}
+}
+
+// WriteTo writes the HTTP Archive as JSON to w.
+func (h *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.MarshalIndent(h.HAR(), "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal HAR: %w", err)
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// Save writes the HTTP Archive to the file at the given path.
+func (h *HARRecorder) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create HAR file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := h.WriteTo(f); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func (h *HARRecorder) harRequest(req *http.Request) HARRequest {
+	u := req.URL
+	r := HARRequest{
+		Method:      req.Method,
+		URL:         u.String(),
+		HTTPVersion: httpVersion(req.Proto),
+		Cookies:     []HARNameValue{},
+		Headers:     h.headers(req.Header),
+		QueryString: []HARNameValue{},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(u.Query())) {
+		for _, value := range u.Query()[name] {
+			r.QueryString = append(r.QueryString, HARNameValue{Name: name, Value: value})
+		}
+	}
+
+	if body, err := readBody(req.Body); err == nil && body != nil {
+		r.BodySize = len(body)
+		r.PostData = &HARPostData{MimeType: req.Header.Get("Content-Type"), Text: string(body)}
+	}
+	return r
+}
+
+func (h *HARRecorder) harResponse(resp *http.Response) HARResponse {
+	r := HARResponse{
+		Status:      resp.StatusCode,
+		StatusText:  strings.TrimSpace(strings.TrimPrefix(resp.Status, fmt.Sprint(resp.StatusCode))),
+		HTTPVersion: httpVersion(resp.Proto),
+		Cookies:     []HARNameValue{},
+		Headers:     h.headers(resp.Header),
+		Content:     HARContent{MimeType: resp.Header.Get("Content-Type")},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+
+	if body, err := readBody(resp.Body); err == nil {
+		r.BodySize = len(body)
+		r.Content.Size = len(body)
+		r.Content.Text = string(body)
+	}
+	return r
+}
+
+// headers converts the given headers to sorted name/value pairs.
+func (h *HARRecorder) headers(header http.Header) []HARNameValue {
+	pairs := []HARNameValue{}
+	for _, name := range slices.Sorted(maps.Keys(header)) {
+		for _, value := range header[name] {
+			pairs = append(pairs, HARNameValue{Name: name, Value: value})
+		}
+	}
+	return pairs
+}
+
+// httpVersion returns the protocol of a request or response, defaulting to HTTP/1.1 if it is unknown.
+func httpVersion(proto string) string {
+	if proto == "" {
+		return "HTTP/1.1"
+	}
+	return proto
+}
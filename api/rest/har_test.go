@@ -0,0 +1,107 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHARRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Header().Set("Set-Cookie", "session=secret-session")
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = rw.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+
+	har := NewHARRecorder(HARRecorderOptions{RedactQueryParams: []string{"api-token"}})
+	client := NewClient(baseURL, nil, WithHTTPListener(har.Listener()))
+	client.SetHeader("Authorization", "Api-Token secret-token")
+
+	_, err := client.POST(t.Context(), "/objects", strings.NewReader(`{"name":"a"}`), RequestOptions{
+		QueryParams: url.Values{"api-token": {"secret-query"}, "page": {"1"}},
+	})
+	require.NoError(t, err)
+
+	entries := har.HAR().Log.Entries
+	require.Len(t, entries, 1)
+	entry := entries[0]
+
+	assert.Equal(t, http.MethodPost, entry.Request.Method)
+	assert.Contains(t, entry.Request.URL, "/objects")
+	assert.Contains(t, entry.Request.QueryString, HARNameValue{Name: "page", Value: "1"})
+	assert.Contains(t, entry.Request.QueryString, HARNameValue{Name: "api-token", Value: redact.Mask})
+	assert.Contains(t, entry.Request.Headers, HARNameValue{Name: "Authorization", Value: redact.Mask})
+	require.NotNil(t, entry.Request.PostData)
+	assert.Equal(t, `{"name":"a"}`, entry.Request.PostData.Text)
+
+	assert.Equal(t, http.StatusCreated, entry.Response.Status)
+	assert.Equal(t, "Created", entry.Response.StatusText)
+	assert.Equal(t, `{"id":"1"}`, entry.Response.Content.Text)
+	assert.Equal(t, "application/json", entry.Response.Content.MimeType)
+	assert.Contains(t, entry.Response.Headers, HARNameValue{Name: "Set-Cookie", Value: redact.Mask})
+	assert.GreaterOrEqual(t, entry.Time, 0.0)
+
+	path := filepath.Join(t.TempDir(), "traffic.har")
+	require.NoError(t, har.Save(path))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal(data, &parsed))
+	assert.Equal(t, "1.2", parsed["log"].(map[string]any)["version"])
+	assert.Equal(t, map[string]any{"name": "dynatrace-configuration-as-code-core", "version": harCreatorVersion()}, parsed["log"].(map[string]any)["creator"])
+	assert.NotEqual(t, "1.2", harCreatorVersion(), "the creator version is the version of the module, not of the HAR format")
+}
+
+func TestHARRecorder_RecordsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	baseURL, _ := url.Parse(server.URL)
+	server.Close()
+
+	har := NewHARRecorder(HARRecorderOptions{})
+	client := NewClient(baseURL, nil, WithHTTPListener(har.Listener()))
+
+	_, err := client.GET(t.Context(), "", RequestOptions{})
+	require.Error(t, err)
+
+	entries := har.HAR().Log.Entries
+	require.Len(t, entries, 1)
+	assert.Equal(t, 0, entries[0].Response.Status)
+	assert.NotEmpty(t, entries[0].Error)
+}
+
+func TestHARRecorder_Unredacted(t *testing.T) {
+	har := NewHARRecorder(HARRecorderOptions{Unredacted: true})
+	req := httptest.NewRequest(http.MethodGet, "https://example.com", nil)
+	req.Header.Set("Authorization", "token")
+
+	har.Listener().onRequest("1", req)
+	assert.Contains(t, har.HAR().Log.Entries[0].Request.Headers, HARNameValue{Name: "Authorization", Value: "token"})
+}
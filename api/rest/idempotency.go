@@ -0,0 +1,149 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// DefaultIdempotencyKeyHeader is the header carrying idempotency keys if IdempotencyOptions.Header is not set.
+const DefaultIdempotencyKeyHeader = "Idempotency-Key"
+
+// LookupFunc looks up the resource a POST request would create, e.g. by its name or externalId.
+// It returns the body of the resource and true if it exists, or false if it does not exist.
+type LookupFunc func(ctx context.Context) (body []byte, found bool, err error)
+
+// Idempotency makes a POST request safe to retry. See RequestOptions.Idempotency and ContextWithIdempotency.
+type Idempotency struct {
+	// Key is sent in the idempotency key header of every attempt, so that APIs supporting idempotency keys can
+	// recognize retries of a request they already processed. If it is empty and WithIdempotency is enabled, a random
+	// key is generated for the request.
+	Key string
+
+	// Lookup is called before a request is retried, for APIs without server-side support of idempotency keys.
+	// If it finds the resource, the previous attempt created it even though its response was lost. The request is not
+	// sent again then, and a 201 Created response carrying the body returned by Lookup is returned instead.
+	// If Lookup fails, the request is not retried either and the error is returned.
+	Lookup LookupFunc
+}
+
+// IdempotencyOptions configure idempotency keys. See WithIdempotency.
+type IdempotencyOptions struct {
+	// Header is the name of the header carrying the key. Defaults to DefaultIdempotencyKeyHeader.
+	Header string
+}
+
+// WithIdempotency enables generating idempotency keys for POST requests. Each call of Client.POST without a Key in its
+// Idempotency gets a random key, which is sent with all of its attempts.
+//
+// Keys only prevent duplicates if the API supports them. For other APIs, see Idempotency.Lookup.
+func WithIdempotency(opts IdempotencyOptions) Option {
+	if opts.Header == "" {
+		opts.Header = DefaultIdempotencyKeyHeader
+	}
+	return func(c *Client) {
+		c.idempotency = &opts
+	}
+}
+
+type idempotencyKey struct{}
+
+// ContextWithIdempotency returns a copy of ctx carrying the given Idempotency. It applies to all POST requests sent
+// with the context whose RequestOptions don't define an Idempotency themselves, which allows making the operations of
+// API clients idempotent, e.g.:
+//
+//	ctx = rest.ContextWithIdempotency(ctx, rest.Idempotency{Key: key, Lookup: findWorkflowByTitle})
+//	resp, err := automationClient.Create(ctx, automation.Workflows, data)
+//
+// As an operation may send several POST requests, e.g. automation.Client.Create retrying without admin access after a
+// 403 Forbidden, the Key is not sent as is. Each distinct request, identified by its method and URL, gets a key derived
+// from it, so that the server never sees the same key for different requests, while retries of the same operation
+// with the same context still send the same keys.
+func ContextWithIdempotency(ctx context.Context, idempotency Idempotency) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, idempotency)
+}
+
+// idempotencyFor returns the Idempotency of the given request with the given RequestOptions. Keys of its context are
+// scoped to the request, see ContextWithIdempotency. A key is generated if WithIdempotency is enabled and none was
+// given.
+func (c *Client) idempotencyFor(req *http.Request, options RequestOptions) Idempotency {
+	if req.Method != http.MethodPost {
+		return options.Idempotency
+	}
+
+	idempotency := options.Idempotency
+	if idempotency.Key == "" && idempotency.Lookup == nil {
+		idempotency, _ = req.Context().Value(idempotencyKey{}).(Idempotency)
+		if idempotency.Key != "" {
+			idempotency.Key = requestScopedKey(idempotency.Key, req)
+		}
+	}
+	if idempotency.Key == "" && c.idempotency != nil {
+		idempotency.Key = uuid.NewString()
+	}
+	return idempotency
+}
+
+// requestScopedKey derives the key of the given request from the given key of its context.
+func requestScopedKey(key string, req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.String()))
+	return key + "-" + hex.EncodeToString(sum[:8])
+}
+
+// IdempotencyMiddleware returns a Middleware sending the idempotency key of each attempt in the given header and
+// checking via Idempotency.Lookup whether a retried request already succeeded.
+func IdempotencyMiddleware(header string) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			idempotency := requestOptionsFrom(req).Idempotency
+			if idempotency.Key != "" {
+				req.Header.Set(header, idempotency.Key)
+			}
+
+			if idempotency.Lookup == nil || attemptFrom(req.Context()) == 0 {
+				return next(req)
+			}
+
+			body, found, err := idempotency.Lookup(req.Context())
+			if err != nil {
+				return nil, fmt.Errorf("failed to check whether a previous attempt of %s %s succeeded: %w", req.Method, RedactorFromContext(req.Context()).URL(req.URL), err)
+			}
+			if !found {
+				return next(req)
+			}
+
+			LoggerFromContext(req.Context()).DebugContext(req.Context(), "Not retrying request, a previous attempt already succeeded", slog.String("url", RedactorFromContext(req.Context()).URL(req.URL).String()))
+			return &http.Response{
+				Status:        "201 Created",
+				StatusCode:    http.StatusCreated,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{"Content-Type": {"application/json"}, "Content-Length": {strconv.Itoa(len(body))}},
+				Body:          newReusableReader(body),
+				ContentLength: int64(len(body)),
+				Request:       req,
+			}, nil
+		}
+	}
+}
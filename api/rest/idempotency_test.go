@@ -0,0 +1,142 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithIdempotency(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		keys = append(keys, req.Header.Get("X-Request-Key"))
+		if len(keys)%2 == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+
+	client := NewClient(baseURL, server.Client(),
+		WithIdempotency(IdempotencyOptions{Header: "X-Request-Key"}),
+		WithRetryOptions(&RetryOptions{MaxRetries: 1, DelayAfterRetry: time.Millisecond, ShouldRetryFunc: RetryIfNotSuccess}),
+	)
+
+	t.Run("generated keys are stable across retries", func(t *testing.T) {
+		keys = nil
+		_, err := client.POST(t.Context(), "", strings.NewReader("{}"), RequestOptions{})
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		assert.NotEmpty(t, keys[0])
+		assert.Equal(t, keys[0], keys[1])
+
+		_, err = client.POST(t.Context(), "", strings.NewReader("{}"), RequestOptions{})
+		require.NoError(t, err)
+		require.Len(t, keys, 4)
+		assert.NotEqual(t, keys[0], keys[2], "each call must get a key of its own")
+	})
+
+	t.Run("given keys are used", func(t *testing.T) {
+		keys = nil
+		_, err := client.POST(t.Context(), "", strings.NewReader("{}"), RequestOptions{Idempotency: Idempotency{Key: "options"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"options", "options"}, keys)
+	})
+
+	t.Run("keys of the context are scoped to a single request", func(t *testing.T) {
+		keys = nil
+		ctx := ContextWithIdempotency(t.Context(), Idempotency{Key: "context"})
+		_, err := client.POST(ctx, "", strings.NewReader("{}"), RequestOptions{QueryParams: url.Values{"adminAccess": {"true"}}})
+		require.NoError(t, err)
+		_, err = client.POST(ctx, "", strings.NewReader("{}"), RequestOptions{})
+		require.NoError(t, err)
+		_, err = client.POST(ctx, "", strings.NewReader("{}"), RequestOptions{})
+		require.NoError(t, err)
+
+		require.Len(t, keys, 6)
+		assert.True(t, strings.HasPrefix(keys[0], "context-"))
+		assert.Equal(t, keys[0], keys[1], "retries must send the same key")
+		assert.NotEqual(t, keys[0], keys[2], "different requests must not share a key")
+		assert.Equal(t, keys[2], keys[4], "the same request sent again with the same context must send the same key")
+	})
+
+	t.Run("no keys for other methods", func(t *testing.T) {
+		keys = nil
+		_, err := client.PUT(t.Context(), "", strings.NewReader("{}"), RequestOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", ""}, keys)
+	})
+}
+
+func TestClient_IdempotencyLookup(t *testing.T) {
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		posts++
+		rw.WriteHeader(http.StatusServiceUnavailable) // the object is created, but the response claims otherwise
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+
+	client := NewClient(baseURL, server.Client(),
+		WithRetryOptions(&RetryOptions{MaxRetries: 2, DelayAfterRetry: time.Millisecond, ShouldRetryFunc: RetryIfNotSuccess}),
+	)
+
+	t.Run("existing resource is returned instead of re-posting", func(t *testing.T) {
+		posts = 0
+		lookups := 0
+		lookup := func(ctx context.Context) ([]byte, bool, error) {
+			lookups++
+			return []byte(`{"id":"created"}`), true, nil
+		}
+
+		resp, err := client.POST(t.Context(), "", strings.NewReader("{}"), RequestOptions{Idempotency: Idempotency{Lookup: lookup}})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assertBody(t, `{"id":"created"}`, resp)
+		assert.Equal(t, 1, posts)
+		assert.Equal(t, 1, lookups, "the lookup must only run before retries")
+	})
+
+	t.Run("request is retried if resource does not exist", func(t *testing.T) {
+		posts = 0
+		lookup := func(ctx context.Context) ([]byte, bool, error) { return nil, false, nil }
+
+		ctx := ContextWithIdempotency(t.Context(), Idempotency{Lookup: lookup})
+		resp, err := client.POST(ctx, "", strings.NewReader("{}"), RequestOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, 3, posts)
+	})
+
+	t.Run("failing lookup aborts retries", func(t *testing.T) {
+		posts = 0
+		lookup := func(ctx context.Context) ([]byte, bool, error) { return nil, false, errors.New("lookup failed") }
+
+		_, err := client.POST(t.Context(), "", strings.NewReader("{}"), RequestOptions{Idempotency: Idempotency{Lookup: lookup}})
+		assert.ErrorContains(t, err, "lookup failed")
+		assert.Equal(t, 1, posts)
+	})
+}
@@ -15,8 +15,13 @@
 package rest
 
 import (
+	"io"
 	"net/http"
 	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
 )
 
 // RequestResponse represents a recorded HTTP request and response.
@@ -52,23 +57,139 @@ func (r *RequestResponse) IsResponse() (*http.Response, bool) {
 
 // HTTPListener is a struct that can be used to listen for HTTP requests and responses
 // and invoke a user-defined callback function.
+//
+// The callback receives redacted copies of requests and responses: sensitive headers, query parameters, JSON body
+// fields and secrets are masked using the Redactor.
 type HTTPListener struct {
 	Callback func(response RequestResponse)
+
+	// Redactor masks sensitive data before requests, responses and errors are passed to the Callback.
+	// If it is nil, the Redactor of the Client sending the request is used, see WithRedactor.
+	Redactor *redact.Redactor
+
+	// Unredacted disables all redaction, so that the Callback receives the requests and responses as they are sent
+	// and received. Only use it if the data passed to the Callback is never logged or shared.
+	Unredacted bool
+}
+
+// redactor returns the Redactor masking the events of the given request, or nil if they are not redacted.
+func (r *HTTPListener) redactor(req *http.Request) *redact.Redactor {
+	switch {
+	case r.Unredacted:
+		return nil
+	case r.Redactor != nil:
+		return r.Redactor
+	case req != nil:
+		return RedactorFromContext(req.Context())
+	default:
+		return redact.Default()
+	}
 }
 
 // onRequest is a method of HTTPListener that is called when an HTTP request is received.
 // It invokes the user-defined callback function with the request information.
 func (r *HTTPListener) onRequest(id string, req *http.Request) {
 	if r != nil {
+		if redactor := r.redactor(req); redactor != nil {
+			req = redactRequest(redactor, req, true)
+		}
 		r.Callback(RequestResponse{ID: id, Timestamp: time.Now(), Request: req})
 	}
 }
 
-// onResponse is a method of HTTPListener that is called when an HTTP response is received.
+// onResponse is a method of HTTPListener that is called when an HTTP response to req is received.
 // It invokes the user-defined callback function with the response information.
-func (r *HTTPListener) onResponse(id string, resp *http.Response, err error) {
+func (r *HTTPListener) onResponse(id string, req *http.Request, resp *http.Response, err error) {
 	if r != nil {
 		reqResp := RequestResponse{ID: id, Timestamp: time.Now(), Response: resp, Error: err}
+		if redactor := r.redactor(req); redactor != nil {
+			reqResp.Response = redactResponse(redactor, resp)
+			reqResp.Error = redactError(redactor, err)
+		}
 		r.Callback(reqResp)
 	}
 }
+
+// HTTPListenerMiddleware returns a Middleware notifying the given HTTPListener about each request and its
+// response or error. Requests and responses are correlated via a unique ID.
+func HTTPListenerMiddleware(listener *HTTPListener) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			reqID := uuid.NewString()
+			listener.onRequest(reqID, req)
+
+			resp, err := next(req)
+			listener.onResponse(reqID, req, resp, err)
+			return resp, err
+		}
+	}
+}
+
+// redactedError is an error whose message was redacted. It still wraps the original error, so that errors.Is and
+// errors.As work as usual.
+type redactedError struct {
+	msg     string
+	wrapped error
+}
+
+func (e redactedError) Error() string { return e.msg }
+func (e redactedError) Unwrap() error { return e.wrapped }
+
+// redactError returns err with a redacted message, or err itself if its message contains nothing to redact.
+func redactError(r *redact.Redactor, err error) error {
+	if err == nil {
+		return nil
+	}
+	if msg := r.String(err.Error()); msg != err.Error() {
+		return redactedError{msg: msg, wrapped: err}
+	}
+	return err
+}
+
+// redactRequest returns a copy of req with redacted URL and headers. If withBody is set, the body is copied and
+// redacted as well, otherwise the copy has no body.
+func redactRequest(r *redact.Redactor, req *http.Request, withBody bool) *http.Request {
+	if req == nil {
+		return nil
+	}
+
+	redacted := new(http.Request)
+	*redacted = *req
+	redacted.URL = r.URL(req.URL)
+	redacted.Header = r.Header(req.Header)
+	redacted.Body, redacted.GetBody = nil, nil
+	if withBody {
+		redacted.Body = redactBody(r, req.Body)
+	}
+	return redacted
+}
+
+// redactResponse returns a copy of resp with redacted headers and body. Its Request is redacted but has no body.
+func redactResponse(r *redact.Redactor, resp *http.Response) *http.Response {
+	if resp == nil {
+		return nil
+	}
+
+	redacted := *resp
+	redacted.Header = r.Header(resp.Header)
+	redacted.Body = redactBody(r, resp.Body)
+	redacted.Request = redactRequest(r, resp.Request, false)
+	return &redacted
+}
+
+// redactBody returns a redacted copy of a body wrapped by ReusableReader, leaving the original body rewound.
+// Other bodies can't be copied without consuming them and are omitted.
+func redactBody(r *redact.Redactor, body io.ReadCloser) io.ReadCloser {
+	if body == nil || body == http.NoBody {
+		return body
+	}
+	if _, ok := asReusableReader(body); !ok {
+		return http.NoBody
+	}
+
+	data, err := readBody(body)
+	if err != nil {
+		return http.NoBody
+	}
+	return newReusableReader(r.Body(data))
+}
@@ -16,9 +16,16 @@ package rest
 
 import (
 	"errors"
-	"github.com/stretchr/testify/assert"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsRequest(t *testing.T) {
@@ -79,9 +86,104 @@ func TestOnResponse(t *testing.T) {
 
 	resp := &http.Response{}
 	err := errors.New("test error")
-	listener.onResponse("testID", resp, err)
+	listener.onResponse("testID", nil, resp, err)
 
 	assert.Equal(t, "testID", capturedResponse.ID)
 	assert.Equal(t, resp, capturedResponse.Response)
 	assert.Equal(t, err, capturedResponse.Error)
 }
+
+func TestHTTPListenerMiddleware_RedactsEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Set-Cookie", "session=secret-session")
+		_, _ = rw.Write([]byte(`{"name":"a","clientSecret":"secret-response"}`))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+
+	var events []RequestResponse
+	client := NewClient(baseURL, nil, WithHTTPListener(&HTTPListener{Callback: func(rr RequestResponse) { events = append(events, rr) }}))
+	client.SetHeader("Authorization", "Api-Token secret-token")
+
+	resp, err := client.POST(t.Context(), "/objects", strings.NewReader(`{"token":"secret-body"}`), RequestOptions{
+		QueryParams: url.Values{"api-token": {"secret-query"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	req := events[0].Request
+	assert.Equal(t, redact.Mask, req.Header.Get("Authorization"))
+	assert.Equal(t, redact.Mask, req.URL.Query().Get("api-token"))
+	body, _ := io.ReadAll(req.Body)
+	assert.JSONEq(t, `{"token":"REDACTED"}`, string(body))
+
+	res := events[1].Response
+	assert.Equal(t, redact.Mask, res.Header.Get("Set-Cookie"))
+	assert.Equal(t, redact.Mask, res.Request.Header.Get("Authorization"))
+	body, _ = io.ReadAll(res.Body)
+	assert.JSONEq(t, `{"name":"a","clientSecret":"REDACTED"}`, string(body))
+
+	// the client still receives the unredacted response
+	assertBody(t, `{"name":"a","clientSecret":"secret-response"}`, resp)
+}
+
+func TestHTTPListenerMiddleware_RedactsSecretsOfClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"echo":"client-s3cr3t"}`))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+
+	var events []RequestResponse
+	client := NewClient(baseURL, nil,
+		WithHTTPListener(&HTTPListener{Callback: func(rr RequestResponse) { events = append(events, rr) }}),
+		WithRedactor(redact.Default().With(redact.Options{Secrets: []string{"client-s3cr3t"}})),
+	)
+	client.SetHeader("X-Custom", "client-s3cr3t")
+
+	_, err := client.GET(t.Context(), "/objects", RequestOptions{})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, redact.Mask, events[0].Request.Header.Get("X-Custom"))
+	body, _ := io.ReadAll(events[1].Response.Body)
+	assert.JSONEq(t, `{"echo":"REDACTED"}`, string(body))
+}
+
+func TestHTTPListenerMiddleware_Unredacted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"name":"a", "clientSecret":"secret-response"}`))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+
+	var events []RequestResponse
+	client := NewClient(baseURL, nil, WithHTTPListener(&HTTPListener{
+		Callback:   func(rr RequestResponse) { events = append(events, rr) },
+		Unredacted: true,
+	}))
+	client.SetHeader("Authorization", "Api-Token secret-token")
+
+	_, err := client.POST(t.Context(), "/objects", strings.NewReader(`{"token":"secret-body", "a":1}`), RequestOptions{})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "Api-Token secret-token", events[0].Request.Header.Get("Authorization"))
+	body, _ := io.ReadAll(events[0].Request.Body)
+	assert.Equal(t, `{"token":"secret-body", "a":1}`, string(body))
+	assertBody(t, `{"name":"a", "clientSecret":"secret-response"}`, events[1].Response)
+}
+
+func TestHTTPListener_CustomRedactor(t *testing.T) {
+	var captured RequestResponse
+	listener := HTTPListener{
+		Callback: func(rr RequestResponse) { captured = rr },
+		Redactor: redact.New(redact.Options{Secrets: []string{"s3cr3t"}}),
+	}
+
+	err := errors.New("failed with s3cr3t")
+	listener.onResponse("testID", nil, nil, err)
+
+	assert.EqualError(t, captured.Error, "failed with "+redact.Mask)
+	assert.ErrorIs(t, captured.Error, err)
+}
@@ -0,0 +1,104 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Keys of the attributes added to log records of a Client.
+const (
+	// LogKeyClient is the API client performing an operation, e.g. "buckets".
+	LogKeyClient = "client"
+	// LogKeyOperation is the name of the operation, as passed to Client.StartOperation, e.g. "buckets.Client.Update".
+	// The attributes passed to Client.StartOperation, like the ID of the resource, are added as well.
+	LogKeyOperation = "operation"
+	// LogKeyAttempt is the number of the current retry attempt of a request, which is zero for the initial request.
+	LogKeyAttempt = "attempt"
+	// LogKeyRequestID is the ID the server assigned to a request, see RequestIDHeader.
+	LogKeyRequestID = "requestId"
+)
+
+// RequestIDHeader is the response header carrying the ID the server assigned to a request.
+const RequestIDHeader = "X-Request-Id"
+
+// WithLogger sets the logger of the Client. If not set, slog.Default() is used.
+//
+// To route or filter the logs of several clients, e.g. one per environment, pass a logger carrying identifying
+// attributes: WithLogger(logger.With("environment", name)).
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// baseLogger returns the logger of the Client.
+func (c *Client) baseLogger() *slog.Logger {
+	if c.logger == nil {
+		return slog.Default()
+	}
+	return c.logger
+}
+
+type loggerKey struct{}
+
+// withLogger returns a copy of ctx carrying the given logger.
+func withLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the logger of the operation or request ctx belongs to, carrying its attributes, see
+// Client.StartOperation. If there is none, slog.Default() is returned.
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// Logger returns the logger API clients should use for logging within the given context: the logger of the operation
+// ctx belongs to, or the logger of the Client if there is none.
+func (c *Client) Logger(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return c.baseLogger()
+}
+
+// operationLogger returns the logger of the Client with the attributes of the given operation.
+func (c *Client) operationLogger(name string, attributes []slog.Attr) *slog.Logger {
+	args := []any{slog.String(LogKeyOperation, name)}
+	if client, _, found := strings.Cut(name, "."); found {
+		args = append(args, slog.String(LogKeyClient, client))
+	}
+	for _, a := range attributes {
+		args = append(args, a)
+	}
+	return c.baseLogger().With(args...)
+}
+
+// requestIDAttr returns the attribute logging the request ID of the given response, if there is one.
+func requestIDAttr(resp *http.Response) []any {
+	if resp == nil {
+		return nil
+	}
+	if id := resp.Header.Get(RequestIDHeader); id != "" {
+		return []any{slog.String(LogKeyRequestID, id)}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logRecords returns the JSON log records written to buf.
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestClient_WithLogger(t *testing.T) {
+	apiHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		apiHits++
+		rw.Header().Set(RequestIDHeader, "request-1")
+		if apiHits == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})).With("environment", "test")
+
+	var perAttempt []*slog.Logger
+	client := NewClient(baseURL, server.Client(),
+		WithLogger(logger),
+		WithRetryOptions(&RetryOptions{MaxRetries: 1, DelayAfterRetry: time.Millisecond, ShouldRetryFunc: RetryIfNotSuccess}),
+		WithMiddleware(func(next Handler) Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				perAttempt = append(perAttempt, LoggerFromContext(req.Context()))
+				return next(req)
+			}
+		}),
+	)
+
+	ctx, end := client.StartOperation(t.Context(), "objects.Client.Update", slog.String("id", "a"))
+	_, err := client.GET(ctx, "", RequestOptions{})
+	end(err)
+	require.NoError(t, err)
+
+	records := logRecords(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "Retrying failed request", records[0]["msg"])
+	assert.Equal(t, "test", records[0]["environment"])
+	assert.Equal(t, "objects", records[0][LogKeyClient])
+	assert.Equal(t, "objects.Client.Update", records[0][LogKeyOperation])
+	assert.Equal(t, "a", records[0]["id"])
+	assert.Equal(t, "request-1", records[0][LogKeyRequestID])
+
+	// middlewares after the RetryStage log the attempt
+	require.Len(t, perAttempt, 2)
+	buf.Reset()
+	perAttempt[1].Info("second attempt")
+	records = logRecords(t, &buf)
+	assert.Equal(t, float64(1), records[0][LogKeyAttempt])
+	assert.Equal(t, "test", records[0]["environment"])
+
+	// requests outside operations use the logger of the client
+	buf.Reset()
+	client.Logger(t.Context()).Info("plain")
+	records = logRecords(t, &buf)
+	assert.Equal(t, "test", records[0]["environment"])
+	assert.NotContains(t, records[0], LogKeyOperation)
+}
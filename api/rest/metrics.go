@@ -0,0 +1,352 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// metricsPrefix is the prefix of all metric names written by Metrics.WritePrometheus.
+const metricsPrefix = "rest_client_"
+
+// statusError is the status label of requests which failed without a response, e.g. due to network errors.
+const statusError = "error"
+
+// DefaultDurationBuckets are the upper bounds, in seconds, of the histogram buckets used by NewMetrics.
+var DefaultDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// MetricLabels identify the requests a metric value belongs to.
+type MetricLabels struct {
+	// Method is the HTTP method of the requests.
+	Method string
+	// Endpoint is the endpoint template of the requests, see RequestOptions.Endpoint.
+	Endpoint string
+	// Status is the status code of the responses, or "error" if no response was received.
+	// It is only set for request metrics.
+	Status string
+}
+
+// MetricsCollector receives measurements of a Client. See WithMetrics.
+// Implementations must be safe for concurrent use.
+type MetricsCollector interface {
+	// ObserveRequest is called once for each attempt to send a request, after its response was received.
+	ObserveRequest(labels MetricLabels, duration time.Duration, bytesSent, bytesReceived int64)
+	// ObserveRetry is called once for each attempt to send a request which is a retry of a previous attempt.
+	ObserveRetry(labels MetricLabels)
+	// ObserveRateLimitWait is called with the time a request was blocked by the RateLimiter.
+	ObserveRateLimitWait(labels MetricLabels, d time.Duration)
+	// ObserveConcurrencyLimitWait is called with the time a request waited for the ConcurrentRequestLimiter.
+	ObserveConcurrencyLimitWait(labels MetricLabels, d time.Duration)
+}
+
+// WithMetrics reports metrics about all requests of the Client to the given MetricsCollector.
+// Use NewMetrics to create a collector which can be exported in the Prometheus text format.
+func WithMetrics(collector MetricsCollector) Option {
+	return func(c *Client) {
+		c.metrics = collector
+	}
+}
+
+// HistogramSnapshot is the state of a histogram at the time a snapshot was taken.
+type HistogramSnapshot struct {
+	// Buckets are the upper bounds of the buckets, in seconds.
+	Buckets []float64
+	// Counts are the cumulative numbers of observations less than or equal to the upper bound of each bucket.
+	Counts []uint64
+	// Count is the total number of observations.
+	Count uint64
+	// Sum is the sum of all observations, in seconds.
+	Sum float64
+}
+
+// MetricsSnapshot is the state of all metrics collected by Metrics at the time the snapshot was taken.
+type MetricsSnapshot struct {
+	// Requests counts the requests sent, by method, endpoint and status.
+	Requests map[MetricLabels]uint64
+	// RequestDuration is the duration of the requests sent, by method, endpoint and status.
+	RequestDuration map[MetricLabels]HistogramSnapshot
+	// BytesSent is the number of bytes sent in request bodies, by method and endpoint.
+	BytesSent map[MetricLabels]uint64
+	// BytesReceived is the number of bytes received in response bodies, by method and endpoint.
+	BytesReceived map[MetricLabels]uint64
+	// Retries counts the retried requests, by method and endpoint.
+	Retries map[MetricLabels]uint64
+	// RateLimitWait is the time requests were blocked by the RateLimiter, by method and endpoint.
+	RateLimitWait map[MetricLabels]HistogramSnapshot
+	// ConcurrencyLimitWait is the time requests waited for the ConcurrentRequestLimiter, by method and endpoint.
+	ConcurrencyLimitWait map[MetricLabels]HistogramSnapshot
+}
+
+// Metrics is a MetricsCollector keeping counters and histograms in memory.
+// Its state can be read via Snapshot or exported in the Prometheus text format via WritePrometheus.
+type Metrics struct {
+	mu      sync.Mutex
+	buckets []float64
+
+	requests             map[MetricLabels]uint64
+	requestDuration      map[MetricLabels]*histogram
+	bytesSent            map[MetricLabels]uint64
+	bytesReceived        map[MetricLabels]uint64
+	retries              map[MetricLabels]uint64
+	rateLimitWait        map[MetricLabels]*histogram
+	concurrencyLimitWait map[MetricLabels]*histogram
+}
+
+// NewMetrics creates new Metrics using the given histogram bucket upper bounds, in seconds.
+// If no buckets are given, DefaultDurationBuckets are used.
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultDurationBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+
+	return &Metrics{
+		buckets:              buckets,
+		requests:             make(map[MetricLabels]uint64),
+		requestDuration:      make(map[MetricLabels]*histogram),
+		bytesSent:            make(map[MetricLabels]uint64),
+		bytesReceived:        make(map[MetricLabels]uint64),
+		retries:              make(map[MetricLabels]uint64),
+		rateLimitWait:        make(map[MetricLabels]*histogram),
+		concurrencyLimitWait: make(map[MetricLabels]*histogram),
+	}
+}
+
+// ObserveRequest implements MetricsCollector.
+func (m *Metrics) ObserveRequest(labels MetricLabels, duration time.Duration, bytesSent, bytesReceived int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[labels]++
+	m.observe(m.requestDuration, labels, duration)
+
+	labels.Status = ""
+	m.bytesSent[labels] += uint64(max(bytesSent, 0))
+	m.bytesReceived[labels] += uint64(max(bytesReceived, 0))
+}
+
+// ObserveRetry implements MetricsCollector.
+func (m *Metrics) ObserveRetry(labels MetricLabels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries[labels]++
+}
+
+// ObserveRateLimitWait implements MetricsCollector.
+func (m *Metrics) ObserveRateLimitWait(labels MetricLabels, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observe(m.rateLimitWait, labels, d)
+}
+
+// ObserveConcurrencyLimitWait implements MetricsCollector.
+func (m *Metrics) ObserveConcurrencyLimitWait(labels MetricLabels, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observe(m.concurrencyLimitWait, labels, d)
+}
+
+func (m *Metrics) observe(histograms map[MetricLabels]*histogram, labels MetricLabels, d time.Duration) {
+	h, ok := histograms[labels]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		histograms[labels] = h
+	}
+	h.observe(m.buckets, d.Seconds())
+}
+
+// Snapshot returns a copy of the current state of all metrics.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return MetricsSnapshot{
+		Requests:             maps.Clone(m.requests),
+		RequestDuration:      m.snapshotHistograms(m.requestDuration),
+		BytesSent:            maps.Clone(m.bytesSent),
+		BytesReceived:        maps.Clone(m.bytesReceived),
+		Retries:              maps.Clone(m.retries),
+		RateLimitWait:        m.snapshotHistograms(m.rateLimitWait),
+		ConcurrencyLimitWait: m.snapshotHistograms(m.concurrencyLimitWait),
+	}
+}
+
+func (m *Metrics) snapshotHistograms(histograms map[MetricLabels]*histogram) map[MetricLabels]HistogramSnapshot {
+	snapshots := make(map[MetricLabels]HistogramSnapshot, len(histograms))
+	for labels, h := range histograms {
+		snapshots[labels] = HistogramSnapshot{
+			Buckets: slices.Clone(m.buckets),
+			Counts:  slices.Clone(h.counts),
+			Count:   h.count,
+			Sum:     h.sum,
+		}
+	}
+	return snapshots
+}
+
+// WritePrometheus writes all metrics to w in the Prometheus text exposition format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	s := m.Snapshot()
+	bw := bufio.NewWriter(w)
+
+	writeCounter(bw, "requests_total", "Number of HTTP requests sent.", s.Requests)
+	writeHistogram(bw, "request_duration_seconds", "Duration of HTTP requests.", s.RequestDuration)
+	writeCounter(bw, "request_bytes_total", "Number of bytes sent in HTTP request bodies.", s.BytesSent)
+	writeCounter(bw, "response_bytes_total", "Number of bytes received in HTTP response bodies.", s.BytesReceived)
+	writeCounter(bw, "retries_total", "Number of retried HTTP requests.", s.Retries)
+	writeHistogram(bw, "rate_limit_wait_seconds", "Time HTTP requests were blocked by the rate limiter.", s.RateLimitWait)
+	writeHistogram(bw, "concurrency_limit_wait_seconds", "Time HTTP requests waited for the concurrent request limiter.", s.ConcurrencyLimitWait)
+
+	return bw.Flush()
+}
+
+// ServeHTTP serves all metrics in the Prometheus text exposition format, so that Metrics can be used as a scrape target.
+func (m *Metrics) ServeHTTP(rw http.ResponseWriter, _ *http.Request) {
+	rw.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(rw)
+}
+
+type histogram struct {
+	counts []uint64 // non-cumulative counts per bucket
+	count  uint64
+	sum    float64
+}
+
+func (h *histogram) observe(buckets []float64, v float64) {
+	h.count++
+	h.sum += v
+	for i, upper := range buckets {
+		if v <= upper {
+			h.counts[i]++
+			break
+		}
+	}
+}
+
+func writeCounter(w io.Writer, name, help string, values map[MetricLabels]uint64) {
+	if len(values) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "# HELP %s%s %s\n# TYPE %s%s counter\n", metricsPrefix, name, help, metricsPrefix, name)
+	for _, labels := range sortedLabels(values) {
+		fmt.Fprintf(w, "%s%s{%s} %d\n", metricsPrefix, name, formatLabels(labels), values[labels])
+	}
+}
+
+func writeHistogram(w io.Writer, name, help string, values map[MetricLabels]HistogramSnapshot) {
+	if len(values) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "# HELP %s%s %s\n# TYPE %s%s histogram\n", metricsPrefix, name, help, metricsPrefix, name)
+	for _, labels := range sortedLabels(values) {
+		h := values[labels]
+		l := formatLabels(labels)
+
+		var cumulative uint64
+		for i, upper := range h.Buckets {
+			cumulative += h.Counts[i]
+			fmt.Fprintf(w, "%s%s_bucket{%s,le=%q} %d\n", metricsPrefix, name, l, strconv.FormatFloat(upper, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(w, "%s%s_bucket{%s,le=\"+Inf\"} %d\n", metricsPrefix, name, l, h.Count)
+		fmt.Fprintf(w, "%s%s_sum{%s} %s\n", metricsPrefix, name, l, strconv.FormatFloat(h.Sum, 'g', -1, 64))
+		fmt.Fprintf(w, "%s%s_count{%s} %d\n", metricsPrefix, name, l, h.Count)
+	}
+}
+
+func sortedLabels[V any](values map[MetricLabels]V) []MetricLabels {
+	return slices.SortedFunc(maps.Keys(values), func(a, b MetricLabels) int {
+		return cmp.Or(cmp.Compare(a.Endpoint, b.Endpoint), cmp.Compare(a.Method, b.Method), cmp.Compare(a.Status, b.Status))
+	})
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatLabels(labels MetricLabels) string {
+	s := fmt.Sprintf(`method="%s",endpoint="%s"`, labelValueEscaper.Replace(labels.Method), labelValueEscaper.Replace(labels.Endpoint))
+	if labels.Status != "" {
+		s += fmt.Sprintf(`,status="%s"`, labelValueEscaper.Replace(labels.Status))
+	}
+	return s
+}
+
+// MetricsMiddleware returns a Middleware reporting each request it passes to the given MetricsCollector.
+// Requests which are retries of a previous attempt are reported via MetricsCollector.ObserveRetry as well.
+func MetricsMiddleware(collector MetricsCollector) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			labels := metricLabels(req)
+			if attemptFrom(req.Context()) > 0 {
+				collector.ObserveRetry(labels)
+			}
+
+			start := time.Now()
+			resp, err := next(req)
+			duration := time.Since(start)
+
+			labels.Status = statusError
+			var bytesReceived int64
+			if err == nil {
+				labels.Status = strconv.Itoa(resp.StatusCode)
+				bytesReceived = bodySize(resp.Body)
+			}
+			collector.ObserveRequest(labels, duration, bodySize(req.Body), bytesReceived)
+
+			return resp, err
+		}
+	}
+}
+
+// metricLabels returns the method and endpoint labels of the given request.
+func metricLabels(req *http.Request) MetricLabels {
+	endpoint := requestOptionsFrom(req).Endpoint
+	if endpoint == "" {
+		endpoint = req.URL.Path
+	}
+	return MetricLabels{Method: req.Method, Endpoint: endpoint}
+}
+
+// bodySize returns the size of a body wrapped by ReusableReader, or 0 for any other body.
+func bodySize(body io.ReadCloser) int64 {
+	if rr, ok := asReusableReader(body); ok {
+		return rr.Size()
+	}
+	return 0
+}
+
+type metricsKey struct{}
+
+// withMetrics returns a copy of ctx carrying the given MetricsCollector, so that the limiting middlewares can report
+// their waiting times.
+func withMetrics(ctx context.Context, collector MetricsCollector) context.Context {
+	return context.WithValue(ctx, metricsKey{}, collector)
+}
+
+// metricsFrom returns the MetricsCollector of the call the request belongs to, or nil if there is none.
+func metricsFrom(req *http.Request) MetricsCollector {
+	collector, _ := req.Context().Value(metricsKey{}).(MetricsCollector)
+	return collector
+}
@@ -0,0 +1,124 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithMetrics(t *testing.T) {
+	apiHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		apiHits++
+		if apiHits == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+		_, _ = rw.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	metrics := NewMetrics()
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, nil,
+		WithMetrics(metrics),
+		WithConcurrentRequestLimit(1),
+		WithRateLimiter(),
+		WithRetryOptions(&RetryOptions{MaxRetries: 1, ShouldRetryFunc: RetryIfNotSuccess}),
+	)
+
+	resp, err := client.PUT(t.Context(), "/objects/1", strings.NewReader(`{"a":1}`), RequestOptions{Endpoint: "/objects/{id}"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	s := metrics.Snapshot()
+	endpoint := MetricLabels{Method: http.MethodPut, Endpoint: "/objects/{id}"}
+	withStatus := func(status string) MetricLabels {
+		l := endpoint
+		l.Status = status
+		return l
+	}
+
+	assert.Equal(t, map[MetricLabels]uint64{withStatus("503"): 1, withStatus("200"): 1}, s.Requests)
+	assert.Equal(t, uint64(1), s.RequestDuration[withStatus("200")].Count)
+	assert.Equal(t, map[MetricLabels]uint64{endpoint: 1}, s.Retries)
+	assert.Equal(t, map[MetricLabels]uint64{endpoint: 14}, s.BytesSent, "expected body to be counted for both attempts")
+	assert.Equal(t, map[MetricLabels]uint64{endpoint: 10}, s.BytesReceived)
+	assert.Equal(t, uint64(2), s.RateLimitWait[endpoint].Count, "expected rate limiter wait to be observed per attempt")
+	assert.Equal(t, uint64(1), s.ConcurrencyLimitWait[endpoint].Count, "expected concurrency limiter wait to be observed per call")
+}
+
+func TestClient_WithMetrics_DefaultsToPathAndRecordsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	baseURL, _ := url.Parse(server.URL)
+	server.Close()
+
+	metrics := NewMetrics()
+	client := NewClient(baseURL, nil, WithMetrics(metrics))
+
+	_, err := client.GET(t.Context(), "/objects", RequestOptions{})
+	require.Error(t, err)
+
+	assert.Equal(t, map[MetricLabels]uint64{{Method: http.MethodGet, Endpoint: "/objects", Status: "error"}: 1}, metrics.Snapshot().Requests)
+}
+
+func TestMetrics_WritePrometheus(t *testing.T) {
+	metrics := NewMetrics(0.1, 1)
+	labels := MetricLabels{Method: http.MethodGet, Endpoint: "/objects/{id}", Status: "200"}
+	metrics.ObserveRequest(labels, 50*time.Millisecond, 0, 42)
+	metrics.ObserveRequest(labels, 500*time.Millisecond, 0, 8)
+
+	var sb strings.Builder
+	require.NoError(t, metrics.WritePrometheus(&sb))
+
+	expected := `# HELP rest_client_requests_total Number of HTTP requests sent.
+# TYPE rest_client_requests_total counter
+rest_client_requests_total{method="GET",endpoint="/objects/{id}",status="200"} 2
+# HELP rest_client_request_duration_seconds Duration of HTTP requests.
+# TYPE rest_client_request_duration_seconds histogram
+rest_client_request_duration_seconds_bucket{method="GET",endpoint="/objects/{id}",status="200",le="0.1"} 1
+rest_client_request_duration_seconds_bucket{method="GET",endpoint="/objects/{id}",status="200",le="1"} 2
+rest_client_request_duration_seconds_bucket{method="GET",endpoint="/objects/{id}",status="200",le="+Inf"} 2
+rest_client_request_duration_seconds_sum{method="GET",endpoint="/objects/{id}",status="200"} 0.55
+rest_client_request_duration_seconds_count{method="GET",endpoint="/objects/{id}",status="200"} 2
+# HELP rest_client_request_bytes_total Number of bytes sent in HTTP request bodies.
+# TYPE rest_client_request_bytes_total counter
+rest_client_request_bytes_total{method="GET",endpoint="/objects/{id}"} 0
+# HELP rest_client_response_bytes_total Number of bytes received in HTTP response bodies.
+# TYPE rest_client_response_bytes_total counter
+rest_client_response_bytes_total{method="GET",endpoint="/objects/{id}"} 50
+`
+	assert.Equal(t, expected, sb.String())
+}
+
+func TestMetrics_ServeHTTP(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.ObserveRetry(MetricLabels{Method: http.MethodGet, Endpoint: `/a"b`})
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, rec.Body.String(), `rest_client_retries_total{method="GET",endpoint="/a\"b"} 1`)
+}
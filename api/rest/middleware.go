@@ -0,0 +1,221 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"net/http"
+	"slices"
+)
+
+// Handler sends an HTTP request and returns its response.
+// The context of the call is available via the request's Context.
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a Handler to add behaviour before and/or after the next Handler of the chain is invoked.
+// Middlewares may modify the request before passing it on, and the response before returning it.
+type Middleware func(next Handler) Handler
+
+// Stage identifies a position in the middleware chain of a Client.
+// Requests pass the stages in the order configured via WithMiddlewareOrder, or DefaultMiddlewareOrder() if not set.
+type Stage int
+
+const (
+	// ConcurrencyLimitStage acquires a slot of the concurrent request limiter for the whole duration of a call,
+	// including all retries. See WithConcurrentRequestLimit and WithAdaptiveConcurrency.
+	ConcurrencyLimitStage Stage = iota
+
+	// HeaderStage sets the "Content-Type" header and the custom headers configured via Client.SetHeader.
+	HeaderStage
+
+	// RetryStage retries failed requests according to the RetryOptions of the Client and the RequestOptions.
+	// All stages after it are invoked once per attempt.
+	RetryStage
+
+	// CustomStage runs the middlewares added via WithMiddleware, in the order they were added.
+	CustomStage
+
+	// RateLimitStage waits until the RateLimiter permits a request and updates it with each response.
+	// See WithRateLimiter.
+	RateLimitStage
+
+	// ListenerStage notifies the HTTPListener about each request and response. See WithHTTPListener.
+	ListenerStage
+
+	// TracingStage traces each request as a span and propagates it via the "traceparent" header. See WithTracer.
+	TracingStage
+
+	// MetricsStage reports each request to the MetricsCollector. See WithMetrics.
+	MetricsStage
+
+	// CircuitBreakerStage fails requests to hosts whose circuit is open fast and reports the results of all other
+	// requests to the CircuitBreaker. See WithCircuitBreaker.
+	CircuitBreakerStage
+
+	// RecorderStage records requests and responses, or replays them instead of sending requests. See WithRecorder.
+	RecorderStage
+
+	// CacheStage sends GET requests as conditional requests and serves them from the Cache if they are not modified.
+	// See WithCache.
+	CacheStage
+
+	// CoalescingStage collapses identical GET requests in flight at the same time into a single call.
+	// See WithRequestCoalescing.
+	CoalescingStage
+	// IdempotencyStage sends the idempotency key of POST requests with each attempt and checks whether a previous
+	// attempt already succeeded before retrying them. See Idempotency and WithIdempotency.
+	IdempotencyStage
+	// DryRunStage intercepts mutating requests in dry-run mode, recording them in the DryRunPlan and answering them
+	// with synthesized responses. See WithDryRun.
+	DryRunStage
+)
+
+// defaultMiddlewareOrder is the order of the middleware chain used if no order is set via WithMiddlewareOrder.
+var defaultMiddlewareOrder = []Stage{CoalescingStage, ConcurrencyLimitStage, HeaderStage, DryRunStage, RetryStage, IdempotencyStage, CircuitBreakerStage, TracingStage, CacheStage, CustomStage, RateLimitStage, ListenerStage, MetricsStage, RecorderStage}
+
+// DefaultMiddlewareOrder returns the order of the middleware chain used if no order is set via WithMiddlewareOrder.
+func DefaultMiddlewareOrder() []Stage {
+	return slices.Clone(defaultMiddlewareOrder)
+}
+
+// WithMiddleware adds custom middlewares to the Client. They are run as part of the CustomStage of the chain, i.e.
+// by default once per attempt after retries are handled and before rate limiting and the HTTPListener are applied.
+// This allows, e.g., to sign each request or to audit requests and responses.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// WithMiddlewareOrder defines the order in which requests pass the stages of the middleware chain.
+// The first stage is the outermost one, i.e. it sees the request first and the response last.
+// Stages which are not listed, but configured for the Client, e.g. the DryRunStage of a Client using WithDryRun, are
+// still applied: each of them is inserted after the closest stage preceding it in DefaultMiddlewareOrder(), or first
+// if there is none. This ensures that no stage added in a later version is skipped silently.
+func WithMiddlewareOrder(stages ...Stage) Option {
+	return func(c *Client) {
+		c.middlewareOrder = slices.Clone(stages)
+	}
+}
+
+// chain builds the Handler sending requests through all configured middleware stages.
+// The chain is assembled for every call, so that it always reflects the current configuration of the Client.
+func (c *Client) chain() Handler {
+	order := c.stageOrder()
+
+	h := concurrencyFeedbackMiddleware(c.transport)
+	for i := len(order) - 1; i >= 0; i-- {
+		middlewares := c.middlewaresOf(order[i])
+		for j := len(middlewares) - 1; j >= 0; j-- {
+			h = middlewares[j](h)
+		}
+	}
+	return h
+}
+
+// stageOrder returns the order of the stages of the middleware chain, see WithMiddlewareOrder.
+func (c *Client) stageOrder() []Stage {
+	if c.middlewareOrder == nil {
+		return defaultMiddlewareOrder
+	}
+
+	order := slices.Clone(c.middlewareOrder)
+	for i, stage := range defaultMiddlewareOrder {
+		if slices.Contains(order, stage) || len(c.middlewaresOf(stage)) == 0 {
+			continue
+		}
+		pos := 0
+		for j := i - 1; j >= 0; j-- {
+			if k := slices.Index(order, defaultMiddlewareOrder[j]); k >= 0 {
+				pos = k + 1
+				break
+			}
+		}
+		order = slices.Insert(order, pos, stage)
+	}
+	return order
+}
+
+// middlewaresOf returns the middlewares configured for the given stage.
+func (c *Client) middlewaresOf(stage Stage) []Middleware {
+	switch stage {
+	case ConcurrencyLimitStage:
+		if c.concurrentRequestLimiter != nil {
+			return []Middleware{ConcurrencyLimitMiddleware(c.concurrentRequestLimiter)}
+		}
+	case HeaderStage:
+		return []Middleware{c.headerMiddleware}
+	case RetryStage:
+		return []Middleware{RetryMiddleware(c.retryOptions)}
+	case CustomStage:
+		return c.middlewares
+	case RateLimitStage:
+		if c.rateLimiter != nil {
+			return []Middleware{RateLimitMiddleware(c.rateLimiter)}
+		}
+	case ListenerStage:
+		if c.httpListener != nil {
+			return []Middleware{HTTPListenerMiddleware(c.httpListener)}
+		}
+	case TracingStage:
+		if c.tracer != nil {
+			return []Middleware{TracingMiddleware(c.tracer)}
+		}
+	case MetricsStage:
+		if c.metrics != nil {
+			return []Middleware{MetricsMiddleware(c.metrics)}
+		}
+	case CircuitBreakerStage:
+		if c.circuitBreaker != nil {
+			return []Middleware{CircuitBreakerMiddleware(c.circuitBreaker)}
+		}
+	case RecorderStage:
+		if c.recorder != nil {
+			return []Middleware{RecorderMiddleware(c.recorder)}
+		}
+	case CacheStage:
+		if c.cache != nil {
+			return []Middleware{CacheMiddleware(c.cache)}
+		}
+	case CoalescingStage:
+		if c.coalescer != nil {
+			return []Middleware{c.coalescer.middleware}
+		}
+	case DryRunStage:
+		if c.dryRun != nil {
+			return []Middleware{c.dryRunMiddleware}
+		}
+	case IdempotencyStage:
+		header := DefaultIdempotencyKeyHeader
+		if c.idempotency != nil {
+			header = c.idempotency.Header
+		}
+		return []Middleware{IdempotencyMiddleware(header)}
+	}
+	return nil
+}
+
+type requestOptionsKey struct{}
+
+// withRequestOptions returns a copy of ctx carrying the given RequestOptions, so that middlewares can access them.
+func withRequestOptions(ctx context.Context, options RequestOptions) context.Context {
+	return context.WithValue(ctx, requestOptionsKey{}, options)
+}
+
+// requestOptionsFrom returns the RequestOptions of the call the request belongs to.
+func requestOptionsFrom(req *http.Request) RequestOptions {
+	options, _ := req.Context().Value(requestOptionsKey{}).(RequestOptions)
+	return options
+}
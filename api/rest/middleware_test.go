@@ -0,0 +1,158 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithMiddleware(t *testing.T) {
+	apiHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		apiHits++
+		assert.Equal(t, strconv.Itoa(apiHits), req.Header.Get("X-Signature"))
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"), "expected headers to be set before custom middlewares run")
+		if apiHits == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	attempts := 0
+	signing := func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			attempts++
+			req.Header.Set("X-Signature", strconv.Itoa(attempts))
+			return next(req)
+		}
+	}
+
+	var seenSignatures []string
+	listener := &HTTPListener{Callback: func(rr RequestResponse) {
+		if req, ok := rr.IsRequest(); ok {
+			seenSignatures = append(seenSignatures, req.Header.Get("X-Signature"))
+		}
+	}}
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, nil,
+		WithMiddleware(signing),
+		WithHTTPListener(listener),
+		WithRetryOptions(&RetryOptions{MaxRetries: 1, ShouldRetryFunc: RetryIfNotSuccess}),
+	)
+
+	resp, err := client.GET(t.Context(), "", RequestOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, attempts, "expected custom middleware to run once per attempt")
+	assert.Equal(t, []string{"1", "2"}, seenSignatures, "expected listener to see requests modified by custom middleware")
+}
+
+func TestClient_WithMiddlewareOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+
+	t.Run("middlewares run in the given order", func(t *testing.T) {
+		var calls []string
+		recorder := func(name string) Middleware {
+			return func(next Handler) Handler {
+				return func(req *http.Request) (*http.Response, error) {
+					calls = append(calls, name)
+					return next(req)
+				}
+			}
+		}
+
+		client := NewClient(baseURL, nil,
+			WithMiddleware(recorder("first"), recorder("second")),
+			WithMiddlewareOrder(CustomStage, HeaderStage, RetryStage),
+			WithRetryOptions(&RetryOptions{MaxRetries: 2, ShouldRetryFunc: RetryIfNotSuccess}),
+		)
+
+		_, err := client.GET(t.Context(), "", RequestOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first", "second"}, calls, "expected custom middlewares to run once, outside of retries")
+	})
+
+	t.Run("configured stages which are not listed are added at their default position", func(t *testing.T) {
+		var records []RequestResponse
+		client := NewClient(baseURL, nil,
+			WithMiddlewareOrder(HeaderStage),
+			WithHTTPListener(&HTTPListener{Callback: func(rr RequestResponse) { records = append(records, rr) }}),
+			WithRetryOptions(&RetryOptions{MaxRetries: 2, ShouldRetryFunc: RetryIfNotSuccess}),
+		)
+
+		assert.Equal(t, []Stage{HeaderStage, RetryStage, IdempotencyStage, ListenerStage}, client.stageOrder())
+
+		resp, err := client.GET(t.Context(), "", RequestOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Len(t, records, 6, "expected the listener to see each attempt")
+	})
+
+	t.Run("dry run is never skipped", func(t *testing.T) {
+		plan := NewDryRunPlan()
+		client := NewClient(baseURL, nil,
+			WithMiddlewareOrder(CustomStage, HeaderStage, RetryStage),
+			WithDryRun(DryRunOptions{Plan: plan}),
+		)
+
+		resp, err := client.DELETE(t.Context(), "objects/1", RequestOptions{})
+		require.NoError(t, err)
+		assert.True(t, IsSuccess(resp), "the request must not be sent")
+		assert.Len(t, plan.Requests(), 1)
+	})
+
+	t.Run("default order can't be modified", func(t *testing.T) {
+		order := DefaultMiddlewareOrder()
+		order[0] = RecorderStage
+		assert.Equal(t, CoalescingStage, DefaultMiddlewareOrder()[0])
+	})
+}
+
+func TestClient_Do_UsesMiddlewares(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "value", req.Header.Get("X-Custom"))
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, nil, WithMiddleware(func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Custom", "value")
+			return next(req)
+		}
+	}))
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
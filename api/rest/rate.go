@@ -37,9 +37,8 @@ type RateLimiter struct {
 	Lock  sync.RWMutex
 	Clock Clock
 
-	limiter      *rate.Limiter
-	resetAt      *time.Time
-	resetTimeout *time.Duration
+	limiter *rate.Limiter
+	resetAt *time.Time
 }
 
 type Clock interface {
@@ -57,6 +56,31 @@ func (realtimeClock) After(d time.Duration) <-chan time.Time {
 	return time.After(d)
 }
 
+// RateLimitMiddleware returns a Middleware blocking requests while the given RateLimiter does not permit them and
+// updating it with the headers of every response.
+func RateLimitMiddleware(rl *RateLimiter) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			err := rl.Wait(req.Context()) // If a limit is reached, this blocks until operations are permitted again
+			waited := time.Since(start)
+			addSpanAttributes(req.Context(), slog.Duration(AttributeRateLimitWait, waited))
+			if collector := metricsFrom(req); collector != nil {
+				collector.ObserveRateLimitWait(metricLabels(req), waited)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("waiting for rate limiter aborted: %w", err)
+			}
+
+			resp, err := next(req)
+			if err == nil {
+				rl.Update(req.Context(), resp.StatusCode, resp.Header)
+			}
+			return resp, err
+		}
+	}
+}
+
 func NewRateLimiter() *RateLimiter {
 	return &RateLimiter{
 		Clock: realtimeClock{},
@@ -71,10 +95,10 @@ func (rl *RateLimiter) Update(ctx context.Context, status int, headers http.Head
 	if err == nil && limit > 0 {
 		if rl.limiter == nil {
 
-			slog.DebugContext(ctx, "Rate limit set based on HTTP response", slog.Float64("requestsPerSecondLimit", float64(limit)))
+			LoggerFromContext(ctx).DebugContext(ctx, "Rate limit set based on HTTP response", slog.Float64("requestsPerSecondLimit", float64(limit)))
 			rl.limiter = rate.NewLimiter(limit, 1)
 		} else if limit != rl.limiter.Limit() {
-			slog.DebugContext(ctx, "Rate limit updated based on HTTP response", slog.Float64("requestsPerSecondLimit", float64(limit)))
+			LoggerFromContext(ctx).DebugContext(ctx, "Rate limit updated based on HTTP response", slog.Float64("requestsPerSecondLimit", float64(limit)))
 			rl.limiter.SetLimit(limit)
 		}
 	}
@@ -82,26 +106,21 @@ func (rl *RateLimiter) Update(ctx context.Context, status int, headers http.Head
 	if status != http.StatusTooManyRequests {
 		// no hard limit reached at the moment, carry on
 		rl.resetAt = nil
-		rl.resetTimeout = nil
 		return
 	}
 
-	reset, err := extractTimeout(headers)
-	if err != nil {
-		slog.DebugContext(ctx, "Failed to extract timeout from 429 TooManyRequests response, using default timeout", slog.Int64("timeoutMillis", defaultTimeout.Milliseconds()), slog.String("error", err.Error()), slog.Any("headers", headers))
-
-		rt := defaultTimeout
-		rl.resetTimeout = &rt
-		ra := rl.Clock.Now().Add(defaultTimeout)
-		rl.resetAt = &ra
-		return
+	// the server may request the timeout either via the Retry-After or the X-RateLimit-Reset header
+	now := rl.Clock.Now()
+	timeout, ok := ServerRequestedDelay(headers, now)
+	if ok {
+		LoggerFromContext(ctx).DebugContext(ctx, "Extracted timeout from 429 TooManyRequests response", slog.Int64("timeoutMillis", timeout.Milliseconds()))
+	} else {
+		LoggerFromContext(ctx).DebugContext(ctx, "Failed to extract timeout from 429 TooManyRequests response, using default timeout", slog.Int64("timeoutMillis", defaultTimeout.Milliseconds()), slog.Any("headers", RedactorFromContext(ctx).Header(headers)))
+		timeout = defaultTimeout
 	}
 
-	rl.resetAt = &reset
-	rt := reset.Sub(rl.Clock.Now())
-	rl.resetTimeout = &rt
-
-	slog.DebugContext(ctx, "Extracted timeout from 429 TooManyRequests response", slog.Int64("timeoutMillis", rl.resetTimeout.Milliseconds()))
+	resetAt := now.Add(timeout)
+	rl.resetAt = &resetAt
 }
 
 // extractLimit tries to parse the limitHeader into a rate.Limit for use with the soft-limit rate.Limiter.
@@ -130,19 +149,30 @@ func extractTimeout(header http.Header) (time.Time, error) {
 // In case of a hard limit, the method will block until after its reset time is reached.
 // In case of the soft request/second limit it will block until requests are available again.
 // See rate.Limiter for details on how soft-limits works.
-func (rl *RateLimiter) Wait(ctx context.Context) {
+// Wait returns an error without waiting any further if ctx is done, or if the soft limit can't be satisfied before
+// the deadline of ctx. Updates of the RateLimiter are not blocked while waiting.
+func (rl *RateLimiter) Wait(ctx context.Context) error {
 	rl.Lock.RLock()
-	defer rl.Lock.RUnlock()
+	var hardLimitWait time.Duration
+	if rl.resetAt != nil {
+		hardLimitWait = rl.resetAt.Sub(rl.Clock.Now())
+	}
+	limiter := rl.limiter
+	rl.Lock.RUnlock()
 
-	if rl.resetAt != nil && rl.resetTimeout != nil && rl.resetAt.After(rl.Clock.Now()) {
+	if hardLimitWait > 0 {
 		// hard limit triggered via 429 API response, wait until its timeout is reached
-		<-rl.Clock.After(*rl.resetTimeout)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-rl.Clock.After(hardLimitWait):
+		}
 	}
 
-	if rl.limiter != nil {
-		err := rl.limiter.Wait(ctx)
-		if err != nil {
-			slog.ErrorContext(ctx, "Client-side rate limiting failed", slog.String("error", err.Error()))
+	if limiter != nil {
+		if err := limiter.Wait(ctx); err != nil {
+			return fmt.Errorf("client-side rate limiting failed: %w", err)
 		}
 	}
+	return ctx.Err()
 }
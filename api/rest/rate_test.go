@@ -0,0 +1,89 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Run("returns immediately without limit", func(t *testing.T) {
+		assert.NoError(t, NewRateLimiter().Wait(t.Context()))
+	})
+
+	t.Run("waits until hard limit is reset", func(t *testing.T) {
+		rl := NewRateLimiter()
+		rl.Update(t.Context(), http.StatusTooManyRequests, http.Header{}) // default timeout
+
+		start := time.Now()
+		require.NoError(t, rl.Wait(t.Context()))
+		assert.GreaterOrEqual(t, time.Since(start), defaultTimeout/2)
+	})
+
+	t.Run("aborts hard limit wait when context is done", func(t *testing.T) {
+		rl := NewRateLimiter()
+		rl.Update(t.Context(), http.StatusTooManyRequests, http.Header{"Retry-After": {"3600"}})
+
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := rl.Wait(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("does not block updates while waiting", func(t *testing.T) {
+		rl := NewRateLimiter()
+		rl.Update(t.Context(), http.StatusTooManyRequests, http.Header{"Retry-After": {"3600"}})
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+		done := make(chan error)
+		go func() { done <- rl.Wait(ctx) }()
+
+		time.Sleep(10 * time.Millisecond) // let Wait start blocking
+		updated := make(chan struct{})
+		go func() {
+			rl.Update(t.Context(), http.StatusOK, http.Header{})
+			close(updated)
+		}()
+
+		select {
+		case <-updated:
+		case <-time.After(time.Second):
+			t.Fatal("expected Update not to be blocked by a waiting request")
+		}
+
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+	})
+
+	t.Run("returns error if soft limit can't be met before deadline", func(t *testing.T) {
+		rl := NewRateLimiter()
+		rl.Update(t.Context(), http.StatusOK, http.Header{"X-Ratelimit-Limit": {"1"}})
+		require.NoError(t, rl.Wait(t.Context())) // consumes the single token
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+		assert.Error(t, rl.Wait(ctx))
+	})
+}
@@ -18,50 +18,167 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
+	"runtime"
+	"sync"
 )
 
-// reusableReader is a reader that can be used multiple times to from an io.ReadCloser
+// reusableReader is a reader that can be used multiple times to from an io.ReadCloser.
+// After reaching EOF, it rewinds to the start of the data.
+// Its data is either held in memory or, for large bodies read in streaming mode, spooled to a temporary file.
 type reusableReader struct {
-	reader  io.Reader
-	readBuf *bytes.Buffer
-	backBuf *bytes.Buffer
+	io.ReadSeeker
+	size  int64
+	data  []byte // content of in-memory bodies
+	spool *spool // temporary file holding the content of spooled bodies
+}
+
+// newReusableReader returns a reusableReader of the given data.
+func newReusableReader(data []byte) reusableReader {
+	return reusableReader{ReadSeeker: bytes.NewReader(data), size: int64(len(data)), data: data}
 }
 
 func (r reusableReader) Close() error {
 	return nil
 }
 
+// Size returns the length of the data in bytes.
+func (r reusableReader) Size() int64 {
+	return r.size
+}
+
+// clone returns a new reader of the same data, positioned at its start. Clones can be read independently and
+// concurrently. The clone of a spooled body holds a reference to the spool, which must be released.
+func (r reusableReader) clone() reusableReader {
+	if r.spool == nil {
+		return newReusableReader(r.data)
+	}
+	r.spool.acquire()
+	return reusableReader{ReadSeeker: io.NewSectionReader(r.spool.file, 0, r.size), size: r.size, spool: r.spool}
+}
+
+// release releases the reference of the reader to its spool, removing the spool if it is no longer used.
+func (r reusableReader) release() {
+	if r.spool != nil {
+		r.spool.release()
+	}
+}
+
+// ReusableReader reads the given io.ReadCloser to completion, closes it and returns a reader which can be read
+// multiple times. If r already is such a reader, it is rewound to its start instead of being read again.
 func ReusableReader(r io.ReadCloser) (io.ReadCloser, error) {
+	return spoolingReusableReader(r, nil)
+}
+
+// spoolingReusableReader works like ReusableReader, but spools bodies exceeding the threshold of the given
+// StreamingOptions to a temporary file instead of holding them in memory. If opts is nil, bodies are never spooled.
+func spoolingReusableReader(r io.ReadCloser, opts *StreamingOptions) (io.ReadCloser, error) {
 	if r == nil {
 		return r, nil
 	}
+	if rr, ok := asReusableReader(r); ok {
+		_, err := rr.Seek(0, io.SeekStart)
+		return rr, err
+	}
 	defer r.Close()
 
-	readBuf := bytes.Buffer{}
-	if _, err := readBuf.ReadFrom(r); err != nil {
-		return nil, err
+	buf := bytes.Buffer{}
+	if opts == nil {
+		if _, err := buf.ReadFrom(r); err != nil {
+			return nil, err
+		}
+		return newReusableReader(buf.Bytes()), nil
 	}
 
-	backBuf := bytes.Buffer{}
-	return reusableReader{
-		reader:  io.TeeReader(&readBuf, &backBuf),
-		readBuf: &readBuf,
-		backBuf: &backBuf,
-	}, nil
+	// read up to one byte more than the threshold to find out whether the body exceeds it
+	if _, err := buf.ReadFrom(io.LimitReader(r, opts.Threshold+1)); err != nil {
+		return nil, err
+	}
+	if int64(buf.Len()) <= opts.Threshold {
+		return newReusableReader(buf.Bytes()), nil
+	}
+	return newSpool(io.MultiReader(&buf, r), opts.Dir)
+}
 
+// asReusableReader returns the reusableReader of the given body, if it is one.
+func asReusableReader(r io.Reader) (reusableReader, bool) {
+	switch rr := r.(type) {
+	case reusableReader:
+		return rr, true
+	case *streamedBody:
+		return rr.reusableReader, true
+	}
+	return reusableReader{}, false
 }
 
 func (r reusableReader) Read(p []byte) (int, error) {
-	n, err := r.reader.Read(p)
+	n, err := r.ReadSeeker.Read(p)
 	if err == io.EOF {
-		if err := r.reset(); err != nil {
-			return n, fmt.Errorf("failed to reset reader after reaching EOF: %w", err)
-		}
+		_, _ = r.Seek(0, io.SeekStart) // seeking to the start of a bytes.Reader or io.SectionReader can't fail
 	}
 	return n, err
 }
 
-func (r reusableReader) reset() error {
-	_, err := io.Copy(r.readBuf, r.backBuf)
-	return err
+// spool is a temporary file holding a body too large to be kept in memory. It is reference counted, as it may be
+// shared by the readers of several callers, and removed once the last reference is released. If references are
+// dropped without being released, e.g. for responses of failed attempts, the file is removed once the spool is
+// garbage collected.
+type spool struct {
+	file *os.File
+
+	mu   sync.Mutex
+	refs int
+}
+
+// newSpool writes r to a new temporary file in dir and returns a reusableReader of it.
+func newSpool(r io.Reader, dir string) (reusableReader, error) {
+	f, err := os.CreateTemp(dir, "rest-body-*")
+	if err != nil {
+		return reusableReader{}, fmt.Errorf("failed to create spool file: %w", err)
+	}
+
+	size, err := io.Copy(f, r)
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return reusableReader{}, fmt.Errorf("failed to spool body: %w", err)
+	}
+
+	s := &spool{file: f, refs: 1}
+	runtime.AddCleanup(s, removeSpoolFile, f)
+	return reusableReader{ReadSeeker: io.NewSectionReader(f, 0, size), size: size, spool: s}, nil
+}
+
+func (s *spool) acquire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refs++
+}
+
+func (s *spool) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refs--
+	if s.refs == 0 {
+		removeSpoolFile(s.file)
+	}
+}
+
+// removeSpoolFile closes and removes the given spool file. It is a no-op if the file was already removed.
+func removeSpoolFile(f *os.File) {
+	if err := f.Close(); err == nil {
+		_ = os.Remove(f.Name())
+	}
+}
+
+// streamedBody is the body of a response returned to the caller of a Client in streaming mode. Closing it releases
+// the spool of the body, so callers must close it once they are done.
+type streamedBody struct {
+	reusableReader
+	once sync.Once
+}
+
+func (b *streamedBody) Close() error {
+	b.once.Do(b.release)
+	return nil
 }
@@ -0,0 +1,372 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/jsondiff"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
+)
+
+// ErrNoRecordedInteraction is returned in ReplayMode for requests which don't match any unused recorded interaction.
+var ErrNoRecordedInteraction = errors.New("no recorded interaction matches request")
+
+// RecorderMode defines whether a Recorder records or replays interactions.
+type RecorderMode int
+
+const (
+	// RecordMode sends all requests to the server and records them together with their responses.
+	RecordMode RecorderMode = iota
+	// ReplayMode answers all requests with recorded responses without sending them to the server.
+	ReplayMode
+)
+
+// MatchField is a part of a request considered when matching it against recorded interactions.
+type MatchField int
+
+const (
+	MatchMethod MatchField = iota
+	MatchPath
+	MatchQuery
+	MatchBody
+)
+
+// Matcher decides whether a request with the given body matches a recorded request. Like recorded requests, the
+// request and its body are redacted before they are matched.
+type Matcher func(req *http.Request, body []byte, recorded RecordedRequest) bool
+
+// NewMatcher returns a Matcher comparing the given fields of requests. If no fields are given, all are compared.
+// JSON bodies are compared semantically, see jsondiff.Equal, and multipart bodies part by part, ignoring their random
+// boundaries.
+func NewMatcher(fields ...MatchField) Matcher {
+	if len(fields) == 0 {
+		fields = []MatchField{MatchMethod, MatchPath, MatchQuery, MatchBody}
+	}
+
+	return func(req *http.Request, body []byte, recorded RecordedRequest) bool {
+		recordedURL, err := url.Parse(recorded.URL)
+		if err != nil {
+			return false
+		}
+
+		for _, f := range fields {
+			var equal bool
+			switch f {
+			case MatchMethod:
+				equal = req.Method == recorded.Method
+			case MatchPath:
+				equal = req.URL.Path == recordedURL.Path
+			case MatchQuery:
+				equal = req.URL.Query().Encode() == recordedURL.Query().Encode()
+			case MatchBody:
+				equal = bodiesEqual(req.Header.Get("Content-Type"), body, recorded.Header.Get("Content-Type"), []byte(recorded.Body))
+			}
+			if !equal {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Cassette holds the interactions recorded by a Recorder. It is stored as JSON.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request together with its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the persisted form of an HTTP request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the persisted form of an HTTP response.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// RecorderOptions configure a Recorder.
+type RecorderOptions struct {
+	// Mode defines whether interactions are recorded or replayed.
+	Mode RecorderMode
+	// Path is the cassette file interactions are written to in RecordMode, or read from in ReplayMode.
+	Path string
+	// Matcher decides which recorded interaction answers a request in ReplayMode. Defaults to NewMatcher().
+	Matcher Matcher
+	// RedactHeaders are additional headers whose values are redacted before interactions are recorded.
+	// The URLs, headers and bodies of all interactions are always redacted using the Redactor of the Client sending
+	// the request, see WithRedactor.
+	RedactHeaders []string
+}
+
+// Recorder records the requests of a Client and their responses to a cassette file, or replays them from it.
+// This allows running tests or reproducing issues offline against real traffic.
+// Requests are recorded as sent by the Client, i.e. after all middlewares were applied.
+type Recorder struct {
+	opts          RecorderOptions
+	redactHeaders []*regexp.Regexp
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder creates a new Recorder. In ReplayMode, the cassette is loaded from RecorderOptions.Path.
+func NewRecorder(opts RecorderOptions) (*Recorder, error) {
+	if opts.Matcher == nil {
+		opts.Matcher = NewMatcher()
+	}
+
+	r := &Recorder{opts: opts, redactHeaders: redact.Names(opts.RedactHeaders...)}
+	if opts.Mode == ReplayMode {
+		data, err := os.ReadFile(opts.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %q: %w", opts.Path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// WithRecorder records or replays all requests of the Client using the given Recorder.
+func WithRecorder(r *Recorder) Option {
+	return func(c *Client) {
+		c.recorder = r
+	}
+}
+
+// Interactions returns a copy of all interactions of the Recorder.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// Save writes all recorded interactions to the cassette file. It is a no-op in ReplayMode.
+func (r *Recorder) Save() error {
+	if r.opts.Mode == ReplayMode {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+
+	if err := os.WriteFile(r.opts.Path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// RecorderMiddleware returns a Middleware recording requests and responses using the given Recorder, or answering
+// requests from its recorded interactions in ReplayMode.
+func RecorderMiddleware(r *Recorder) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			body, err := readBody(req.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			if r.opts.Mode == ReplayMode {
+				return r.replay(req, body)
+			}
+
+			resp, err := next(req)
+			if err != nil {
+				return resp, err
+			}
+			if err := r.record(req, body, resp); err != nil {
+				return nil, err
+			}
+			return resp, nil
+		}
+	}
+}
+
+// redactor returns the Redactor masking the interactions of the given request.
+func (r *Recorder) redactor(req *http.Request) *redact.Redactor {
+	return RedactorFromContext(req.Context()).With(redact.Options{Headers: r.redactHeaders})
+}
+
+func (r *Recorder) record(req *http.Request, reqBody []byte, resp *http.Response) error {
+	respBody, err := readBody(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	redactor := r.redactor(req)
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    redactor.URL(req.URL).String(),
+			Header: redactor.Header(req.Header),
+			Body:   string(redactor.Body(reqBody)),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactor.Header(resp.Header),
+			Body:       string(redactor.Body(respBody)),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	return nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	// recorded requests are redacted, so the request must be redacted the same way to match them
+	redactor := r.redactor(req)
+	redacted, redactedBody := redactRequest(redactor, req, false), redactor.Body(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.opts.Matcher(redacted, redactedBody, interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+
+		respBody, _ := ReusableReader(io.NopCloser(strings.NewReader(interaction.Response.Body))) // reading from a strings.Reader can't fail
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          respBody,
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoRecordedInteraction, req.Method, redacted.URL)
+}
+
+// bodiesEqual returns whether the given bodies of the given content types are equal. Multipart bodies are equal if
+// all their parts are equal, JSON documents if they are equal in canonical form.
+func bodiesEqual(contentType string, body []byte, recordedContentType string, recorded []byte) bool {
+	if bytes.Equal(body, recorded) {
+		return true
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	recordedMediaType, recordedParams, _ := mime.ParseMediaType(recordedContentType)
+	if strings.HasPrefix(mediaType, "multipart/") && mediaType == recordedMediaType {
+		return multipartEqual(body, params["boundary"], recorded, recordedParams["boundary"])
+	}
+
+	equal, err := jsondiff.Equal(body, recorded, jsondiff.Options{})
+	return err == nil && equal
+}
+
+// multipartEqual returns whether the given multipart bodies consist of equal parts, see bodiesEqual.
+func multipartEqual(body []byte, boundary string, recorded []byte, recordedBoundary string) bool {
+	parts, err := readParts(body, boundary)
+	if err != nil {
+		return false
+	}
+	recordedParts, err := readParts(recorded, recordedBoundary)
+	if err != nil || len(parts) != len(recordedParts) {
+		return false
+	}
+
+	for i, p := range parts {
+		r := recordedParts[i]
+		if !maps.EqualFunc(p.header, r.header, slices.Equal) ||
+			!bodiesEqual(p.header.Get("Content-Type"), p.content, r.header.Get("Content-Type"), r.content) {
+			return false
+		}
+	}
+	return true
+}
+
+type part struct {
+	header  textproto.MIMEHeader
+	content []byte
+}
+
+// readParts returns all parts of the given multipart body.
+func readParts(body []byte, boundary string) ([]part, error) {
+	if boundary == "" {
+		return nil, errors.New("missing multipart boundary")
+	}
+
+	var parts []part
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		p, err := reader.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(p)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part{header: p.Header, content: content})
+	}
+}
+
+// readBody returns the content of a body wrapped by ReusableReader, leaving it rewound.
+func readBody(body io.ReadCloser) ([]byte, error) {
+	if body == nil || body == http.NoBody {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(body); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,185 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		rw.Header().Set("X-Request-Id", "abc")
+		switch req.Method {
+		case http.MethodPost:
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{"created":` + string(body) + `}`))
+		default:
+			_, _ = rw.Write([]byte(`{"page":"` + req.URL.Query().Get("page") + `"}`))
+		}
+	}))
+	baseURL, _ := url.Parse(server.URL)
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	// record
+	recorder, err := NewRecorder(RecorderOptions{Mode: RecordMode, Path: cassette, RedactHeaders: []string{"X-Secret"}})
+	require.NoError(t, err)
+	client := NewClient(baseURL, nil, WithRecorder(recorder))
+	client.SetHeader("Authorization", "Api-Token secret-token")
+	client.SetHeader("X-Secret", "secret-value")
+
+	_, err = client.POST(t.Context(), "/objects", strings.NewReader(`{"a":1}`), RequestOptions{})
+	require.NoError(t, err)
+	_, err = client.GET(t.Context(), "/objects", RequestOptions{QueryParams: url.Values{"page": {"1"}}})
+	require.NoError(t, err)
+	_, err = client.GET(t.Context(), "/objects", RequestOptions{QueryParams: url.Values{"page": {"2"}}})
+	require.NoError(t, err)
+	require.NoError(t, recorder.Save())
+	server.Close()
+
+	data, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-token")
+	assert.NotContains(t, string(data), "secret-value")
+	assert.Contains(t, string(data), redact.Mask)
+
+	// replay, in a different order than recorded
+	replayer, err := NewRecorder(RecorderOptions{Mode: ReplayMode, Path: cassette})
+	require.NoError(t, err)
+	client = NewClient(baseURL, nil, WithRecorder(replayer))
+
+	resp, err := client.GET(t.Context(), "/objects", RequestOptions{QueryParams: url.Values{"page": {"2"}}})
+	require.NoError(t, err)
+	assertBody(t, `{"page":"2"}`, resp)
+	assert.Equal(t, "abc", resp.Header.Get("X-Request-Id"))
+
+	resp, err = client.POST(t.Context(), "/objects", strings.NewReader(`{"a":1}`), RequestOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assertBody(t, `{"created":{"a":1}}`, resp)
+
+	t.Run("requests with different body don't match", func(t *testing.T) {
+		_, err := client.POST(t.Context(), "/objects", strings.NewReader(`{"a":2}`), RequestOptions{})
+		assert.ErrorIs(t, err, ErrNoRecordedInteraction)
+	})
+
+	t.Run("interactions are replayed only once", func(t *testing.T) {
+		_, err := client.GET(t.Context(), "/objects", RequestOptions{QueryParams: url.Values{"page": {"2"}}})
+		assert.ErrorIs(t, err, ErrNoRecordedInteraction)
+	})
+}
+
+func TestRecorder_RedactsInteractions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"clientSecret":"secret-response","echo":"client-s3cr3t"}`))
+	}))
+	baseURL, _ := url.Parse(server.URL)
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	clientRedactor := WithRedactor(redact.Default().With(redact.Options{Secrets: []string{"client-s3cr3t"}}))
+	send := func(client *Client) (*http.Response, error) {
+		return client.POST(t.Context(), "/objects", strings.NewReader(`{"token":"secret-body","a":1}`), RequestOptions{
+			QueryParams: url.Values{"api-token": {"secret-query"}},
+		})
+	}
+
+	recorder, err := NewRecorder(RecorderOptions{Mode: RecordMode, Path: cassette})
+	require.NoError(t, err)
+	_, err = send(NewClient(baseURL, nil, WithRecorder(recorder), clientRedactor))
+	require.NoError(t, err)
+	require.NoError(t, recorder.Save())
+	server.Close()
+
+	data, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+	for _, secret := range []string{"secret-query", "secret-body", "secret-response", "client-s3cr3t"} {
+		assert.NotContains(t, string(data), secret)
+	}
+
+	replayer, err := NewRecorder(RecorderOptions{Mode: ReplayMode, Path: cassette})
+	require.NoError(t, err)
+	resp, err := send(NewClient(baseURL, nil, WithRecorder(replayer), clientRedactor))
+	require.NoError(t, err)
+	assertBody(t, `{"clientSecret":"REDACTED","echo":"REDACTED"}`, resp)
+}
+
+func TestNewMatcher(t *testing.T) {
+	recorded := RecordedRequest{Method: http.MethodGet, URL: "https://example.com/objects?page=1", Body: "a"}
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/objects?page=2", nil)
+
+	assert.False(t, NewMatcher()(req, []byte("a"), recorded))
+	assert.True(t, NewMatcher(MatchMethod, MatchPath, MatchBody)(req, []byte("a"), recorded))
+	assert.False(t, NewMatcher(MatchMethod, MatchPath, MatchBody)(req, []byte("b"), recorded))
+	assert.True(t, NewMatcher(MatchPath)(req, []byte("b"), recorded))
+}
+
+func TestNewMatcher_Bodies(t *testing.T) {
+	multipartBody := func(boundary string, content string) (string, []byte) {
+		var buf bytes.Buffer
+		w := multipart.NewWriter(&buf)
+		require.NoError(t, w.SetBoundary(boundary))
+		require.NoError(t, w.WriteField("name", "n"))
+		require.NoError(t, w.WriteField("content", content))
+		require.NoError(t, w.Close())
+		return w.FormDataContentType(), buf.Bytes()
+	}
+	request := func(contentType string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "https://example.com/objects", nil)
+		req.Header.Set("Content-Type", contentType)
+		return req
+	}
+
+	t.Run("multipart bodies are compared part by part", func(t *testing.T) {
+		recordedType, recordedBody := multipartBody("recorded-boundary", "content")
+		recorded := RecordedRequest{Method: http.MethodPost, URL: "https://example.com/objects", Header: http.Header{"Content-Type": {recordedType}}, Body: string(recordedBody)}
+
+		contentType, body := multipartBody("other-boundary", "content")
+		assert.True(t, NewMatcher()(request(contentType), body, recorded))
+
+		contentType, body = multipartBody("other-boundary", "changed")
+		assert.False(t, NewMatcher()(request(contentType), body, recorded))
+	})
+
+	t.Run("JSON bodies are compared semantically", func(t *testing.T) {
+		recorded := RecordedRequest{Method: http.MethodPost, URL: "https://example.com/objects", Body: `{"a": 1, "b": [1.0]}`}
+
+		assert.True(t, NewMatcher()(request("application/json"), []byte(`{"b":[1],"a":1}`), recorded))
+		assert.False(t, NewMatcher()(request("application/json"), []byte(`{"b":[2],"a":1}`), recorded))
+	})
+}
+
+func TestNewRecorder_MissingCassette(t *testing.T) {
+	_, err := NewRecorder(RecorderOptions{Mode: ReplayMode, Path: filepath.Join(t.TempDir(), "missing.json")})
+	assert.Error(t, err)
+}
+
+func assertBody(t *testing.T, expected string, resp *http.Response) {
+	t.Helper()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(body))
+}
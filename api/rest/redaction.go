@@ -0,0 +1,77 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
+)
+
+// WithRedactor sets the redact.Redactor of the Client, which masks secrets in its log output, in the requests and
+// responses passed to its HTTPListener and in the errors of its requests. If not set, redact.Default() is used.
+//
+// Secrets only known to one client, like its API token, should be added to its own Redactor rather than to the default
+// one, e.g. WithRedactor(redact.Default().With(redact.Options{Secrets: []string{token}})).
+func WithRedactor(redactor *redact.Redactor) Option {
+	return func(c *Client) {
+		c.redactor = redactor
+	}
+}
+
+// Redactor returns the redact.Redactor of the Client, or redact.Default() if none was set.
+func (c *Client) Redactor() *redact.Redactor {
+	if c.redactor == nil {
+		return redact.Default()
+	}
+	return c.redactor
+}
+
+type redactorKey struct{}
+
+// withRedactor returns a copy of ctx carrying the given Redactor.
+func withRedactor(ctx context.Context, redactor *redact.Redactor) context.Context {
+	return context.WithValue(ctx, redactorKey{}, redactor)
+}
+
+// RedactorFromContext returns the redact.Redactor of the Client sending the request ctx belongs to. If there is none,
+// redact.Default() is returned.
+func RedactorFromContext(ctx context.Context) *redact.Redactor {
+	if redactor, ok := ctx.Value(redactorKey{}).(*redact.Redactor); ok {
+		return redactor
+	}
+	return redact.Default()
+}
+
+// requestError is an error of a request sent by a Client. It carries the Redactor of the Client, so that errors
+// wrapping it can be redacted the same way, see RedactorFromError.
+type requestError struct {
+	err      error
+	redactor *redact.Redactor
+}
+
+func (e *requestError) Error() string { return e.redactor.String(e.err.Error()) }
+func (e *requestError) Unwrap() error { return e.err }
+
+// RedactorFromError returns the redact.Redactor of the Client whose request failed with err, if err is or wraps an
+// error returned by a Client.
+func RedactorFromError(err error) (*redact.Redactor, bool) {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return reqErr.redactor, true
+	}
+	return nil, false
+}
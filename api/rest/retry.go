@@ -15,17 +15,191 @@
 package rest
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 )
 
+const (
+	retryAfterHeader = "Retry-After"
+
+	// defaultMaxRetryAfter is the upper bound for server-requested retry delays if RetryOptions.MaxRetryAfter is not set.
+	defaultMaxRetryAfter = time.Minute
+)
+
 type RetryFunc func(resp *http.Response) bool
 
+// RetryOnErrorFunc decides whether a request that failed without receiving any response should be retried.
+type RetryOnErrorFunc func(err error) bool
+
 // RetryOptions represents a component for retrying failed HTTP requests.
 type RetryOptions struct {
+	// DelayAfterRetry is the constant delay between retries. It is only used if no Backoff is set.
 	DelayAfterRetry time.Duration
 	MaxRetries      int
 	ShouldRetryFunc RetryFunc
+
+	// ShouldRetryOnErrorFunc optionally enables retrying requests that failed on transport level, e.g. due to a
+	// dropped connection. Such retries use the same MaxRetries, Backoff and MaxElapsedTime budget as retries of
+	// failed responses. See RetryIfTransientNetworkError for a default classification of retryable errors.
+	ShouldRetryOnErrorFunc RetryOnErrorFunc
+
+	// Backoff optionally defines the strategy used to compute the delay between retries,
+	// e.g. ExponentialBackoff or DecorrelatedJitterBackoff.
+	Backoff Backoff
+
+	// MaxDelay optionally caps the delay between two retries.
+	MaxDelay time.Duration
+
+	// MaxElapsedTime optionally limits the total time spent retrying a request. No further retry is attempted
+	// if waiting for it would exceed this budget, and the last response is returned instead.
+	MaxElapsedTime time.Duration
+
+	// IgnoreRetryAfter disables deriving the delay between retries from the Retry-After or X-RateLimit-Reset
+	// headers of the failed response. By default, a delay requested by the server takes precedence over the Backoff.
+	IgnoreRetryAfter bool
+
+	// MaxRetryAfter optionally caps delays requested by the server via response headers.
+	// If not set, server-requested delays are capped at one minute.
+	MaxRetryAfter time.Duration
+}
+
+// shouldRetry decides whether another attempt should be made after the given response or transport error.
+func (o RetryOptions) shouldRetry(ctx context.Context, resp *http.Response, err error, retryCount int) bool {
+	if retryCount >= o.MaxRetries {
+		return false
+	}
+
+	if err != nil {
+		// errors caused by the request's own context being cancelled are never retried
+		return ctx.Err() == nil && o.ShouldRetryOnErrorFunc != nil && o.ShouldRetryOnErrorFunc(err)
+	}
+
+	return ShouldRetry(resp.StatusCode) && o.ShouldRetryFunc != nil && o.ShouldRetryFunc(resp)
+}
+
+// delayFor returns the delay to wait before the given retry attempt of the failed response. If the server requested a
+// specific delay via response headers, it is used instead of the configured backoff.
+// resp may be nil if the previous attempt failed without a response.
+func (o RetryOptions) delayFor(resp *http.Response, attempt int, previous time.Duration) time.Duration {
+	if resp != nil && !o.IgnoreRetryAfter {
+		if delay, ok := ServerRequestedDelay(resp.Header, time.Now()); ok {
+			maxDelay := o.MaxRetryAfter
+			if maxDelay <= 0 {
+				maxDelay = defaultMaxRetryAfter
+			}
+			return min(delay, maxDelay)
+		}
+	}
+	return o.nextDelay(attempt, previous)
+}
+
+// nextDelay returns the delay to wait before the given retry attempt.
+func (o RetryOptions) nextDelay(attempt int, previous time.Duration) time.Duration {
+	var delay time.Duration
+	if o.Backoff != nil {
+		delay = o.Backoff.Next(attempt, previous)
+	} else {
+		delay = o.DelayAfterRetry
+	}
+
+	if o.MaxDelay > 0 && delay > o.MaxDelay {
+		return o.MaxDelay
+	}
+	return delay
+}
+
+// RetryMiddleware returns a Middleware retrying failed requests according to the given RetryOptions, which may be
+// overridden per request via RequestOptions. Waits between retries are aborted as soon as the request's context is done.
+func RetryMiddleware(opts *RetryOptions) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			options := requestOptionsFrom(req)
+			// merge client retry options with request retry options
+			retryOptions := mergeRetryOptions(opts, options.CustomShouldRetryFunc, options.DelayAfterRetry, options.MaxRetries)
+			return retryOptions.send(req, next)
+		}
+	}
+}
+
+// send sends the request using next, retrying it as long as the RetryOptions permit.
+func (o RetryOptions) send(req *http.Request, next Handler) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+	redactedURL := RedactorFromContext(ctx).URL(req.URL).String()
+	var delay time.Duration
+	for retryCount := 0; ; retryCount++ {
+		response, err := next(req.WithContext(withAttempt(ctx, retryCount)))
+		if !o.shouldRetry(ctx, response, err, retryCount) {
+			return response, err
+		}
+
+		reason := []any{slog.String("url", redactedURL), slog.Int("retryCount", retryCount)}
+		if err != nil {
+			reason = append(reason, slog.String("error", err.Error()))
+		} else {
+			reason = append(reason, slog.Int("status", response.StatusCode))
+			reason = append(reason, requestIDAttr(response)...)
+		}
+
+		delay = o.delayFor(response, retryCount+1, delay)
+		if o.MaxElapsedTime > 0 && time.Since(start)+delay > o.MaxElapsedTime {
+			LoggerFromContext(ctx).DebugContext(ctx, "Not retrying failed request, retry time budget exhausted", append(reason, slog.Int64("budgetMillis", o.MaxElapsedTime.Milliseconds()))...)
+			return response, err
+		}
+
+		LoggerFromContext(ctx).DebugContext(ctx, "Retrying failed request", append(reason, slog.Int64("delayMillis", delay.Milliseconds()), slog.Int("maxRetryCount", o.MaxRetries))...)
+		if err := sleep(ctx, delay); err != nil {
+			if response != nil {
+				_ = response.Body.Close()
+			}
+			return nil, fmt.Errorf("retrying request %s %s aborted: %w", req.Method, redactedURL, err)
+		}
+	}
+}
+
+type attemptKey struct{}
+
+// withAttempt returns a copy of ctx carrying the number of the current retry attempt, which is also added to its logger.
+func withAttempt(ctx context.Context, retryCount int) context.Context {
+	ctx = withLogger(ctx, LoggerFromContext(ctx).With(slog.Int(LogKeyAttempt, retryCount)))
+	return context.WithValue(ctx, attemptKey{}, retryCount)
+}
+
+// attemptFrom returns the number of the current retry attempt, which is zero for the initial request.
+func attemptFrom(ctx context.Context) int {
+	retryCount, _ := ctx.Value(attemptKey{}).(int)
+	return retryCount
+}
+
+// mergeRetryOptions merges the client-set retry options with the options specified for a specific request.
+// The options for the request are preferred over the ones for the client
+func mergeRetryOptions(clientOptions *RetryOptions, retryFunc RetryFunc, delay *time.Duration, maxRetries *int) RetryOptions {
+	mergedOptions := RetryOptions{}
+	if clientOptions != nil {
+		mergedOptions = *clientOptions
+	}
+	if retryFunc != nil {
+		mergedOptions.ShouldRetryFunc = retryFunc
+	}
+	if delay != nil {
+		// a constant delay requested for a single request takes precedence over the client's backoff strategy
+		mergedOptions.DelayAfterRetry = *delay
+		mergedOptions.Backoff = nil
+	}
+	if maxRetries != nil {
+		mergedOptions.MaxRetries = *maxRetries
+	}
+	return mergedOptions
 }
 
 // RetryIfNotSuccess is a basic retry function which will retry on any non 2xx status code.
@@ -43,6 +217,33 @@ func RetryOnFailureExcept404(resp *http.Response) bool {
 	return RetryIfNotSuccess(resp) && (resp.StatusCode != http.StatusNotFound)
 }
 
+// RetryIfTransientNetworkError returns true for transport errors which are likely to be resolved by trying again:
+// connections closed unexpectedly or reset by the peer, timeouts (including TLS handshake timeouts), temporary DNS
+// failures and HTTP/2 connections shut down by the server via GOAWAY.
+func RetryIfTransientNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	// the HTTP/2 implementation bundled with net/http does not export its GOAWAY error type
+	return strings.Contains(err.Error(), "GOAWAY")
+}
+
 // isStatusSuccess returns true if it is a 2xx status code
 func isStatusSuccess(statusCode int) bool {
 	return statusCode >= 200 && statusCode <= 299
@@ -61,3 +262,28 @@ func ShouldRetry(statusCode int) bool {
 		return true
 	}
 }
+
+// ServerRequestedDelay extracts the delay requested by the server from the given response headers, relative to now.
+// The Retry-After header is supported both as delay in seconds and as HTTP-date. If it is not present, the
+// X-RateLimit-Reset header is evaluated. Reset times in the past result in a delay of zero.
+func ServerRequestedDelay(header http.Header, now time.Time) (time.Duration, bool) {
+	if v := strings.TrimSpace(header.Get(retryAfterHeader)); v != "" {
+		if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+			if seconds > math.MaxInt64/int64(time.Second) {
+				return time.Duration(math.MaxInt64), true
+			}
+			return max(time.Duration(seconds)*time.Second, 0), true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(t.Sub(now), 0), true
+		}
+	}
+
+	if header.Get(resetHeader) != "" {
+		if reset, err := extractTimeout(header); err == nil {
+			return max(reset.Sub(now), 0), true
+		}
+	}
+
+	return 0, false
+}
@@ -15,7 +15,14 @@
 package rest_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"syscall"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -39,3 +46,35 @@ func TestShouldRetry(t *testing.T) {
 		assert.True(t, got)
 	})
 }
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRetryIfTransientNetworkError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"connection closed unexpectedly", &url.Error{Op: "Get", URL: "https://example.com", Err: io.EOF}, true},
+		{"unexpected EOF", fmt.Errorf("reading body: %w", io.ErrUnexpectedEOF), true},
+		{"connection reset", &net.OpError{Op: "read", Err: syscall.ECONNRESET}, true},
+		{"broken pipe", &net.OpError{Op: "write", Err: syscall.EPIPE}, true},
+		{"net timeout", &url.Error{Op: "Get", URL: "https://example.com", Err: timeoutError{}}, true},
+		{"temporary DNS failure", &net.DNSError{Err: "server misbehaving", IsTemporary: true}, true},
+		{"DNS not found", &net.DNSError{Err: "no such host", IsNotFound: true}, false},
+		{"HTTP/2 GOAWAY", errors.New("http2: server sent GOAWAY and closed the connection"), true},
+		{"context cancelled", context.Canceled, false},
+		{"other error", errors.New("something else"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rest.RetryIfTransientNetworkError(tt.err))
+		})
+	}
+}
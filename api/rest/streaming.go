@@ -0,0 +1,64 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"io"
+	"net/http"
+)
+
+const defaultStreamingThreshold = 1 << 20 // 1 MiB
+
+// StreamingOptions configure the streaming mode of a Client. See WithStreaming.
+type StreamingOptions struct {
+	// Threshold is the size in bytes above which bodies are spooled to disk. Defaults to 1 MiB.
+	Threshold int64
+	// Dir is the directory spool files are created in. Defaults to the default directory for temporary files.
+	Dir string
+}
+
+// WithStreaming enables the streaming mode of the Client for large transfers.
+//
+// Request and response bodies are still re-readable, e.g. for retries or by an HTTPListener, but bodies larger than
+// StreamingOptions.Threshold are spooled to a temporary file instead of being held in memory. Retries re-read the
+// spooled file. Spooled response bodies are removed once the caller closes the body, so callers must always close
+// response bodies in streaming mode. To process a response without reading it into memory, use the
+// http.Response.Body directly or api.NewStreamResponseFromHTTPResponse.
+//
+// Note that components which need the complete content of a body, such as an HTTPListener, a Recorder, a Cache, or
+// the redaction of errors, still read spooled bodies into memory.
+func WithStreaming(opts StreamingOptions) Option {
+	if opts.Threshold <= 0 {
+		opts.Threshold = defaultStreamingThreshold
+	}
+	return func(c *Client) {
+		c.streaming = &opts
+	}
+}
+
+// reusableBody wraps the given body using spoolingReusableReader, according to the streaming mode of the Client.
+func (c *Client) reusableBody(body io.ReadCloser) (io.ReadCloser, error) {
+	return spoolingReusableReader(body, c.streaming)
+}
+
+// streamResponse hands the spool of a spooled response body over to the caller, who releases it by closing the body.
+func streamResponse(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	if rr, ok := resp.Body.(reusableReader); ok && rr.spool != nil {
+		resp.Body = &streamedBody{reusableReader: rr}
+	}
+}
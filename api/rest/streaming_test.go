@@ -0,0 +1,135 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func spoolFiles(t *testing.T, dir string) int {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	return len(entries)
+}
+
+func TestClient_WithStreaming(t *testing.T) {
+	large := strings.Repeat("a", 2048)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(req.URL.Query().Get("size")))
+		if req.URL.Query().Get("size") == "large" {
+			_, _ = rw.Write([]byte(large))
+		}
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	dir := t.TempDir()
+
+	client := NewClient(baseURL, nil, WithStreaming(StreamingOptions{Threshold: 1024, Dir: dir}))
+
+	t.Run("small bodies are kept in memory", func(t *testing.T) {
+		resp, err := client.GET(t.Context(), "", RequestOptions{QueryParams: url.Values{"size": {"small"}}})
+		require.NoError(t, err)
+		assertBody(t, "small", resp)
+		assert.Zero(t, spoolFiles(t, dir))
+	})
+
+	t.Run("large bodies are spooled until closed", func(t *testing.T) {
+		resp, err := client.GET(t.Context(), "", RequestOptions{QueryParams: url.Values{"size": {"large"}}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, spoolFiles(t, dir))
+
+		assertBody(t, "large"+large, resp)
+		assertBody(t, "large"+large, resp)
+
+		require.NoError(t, resp.Body.Close())
+		assert.Zero(t, spoolFiles(t, dir))
+	})
+}
+
+func TestClient_WithStreaming_RetriesReadSpooledRequestBody(t *testing.T) {
+	large := strings.Repeat("b", 2048)
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		received = append(received, string(body))
+		if len(received) == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	dir := t.TempDir()
+
+	client := NewClient(baseURL, nil,
+		WithStreaming(StreamingOptions{Threshold: 1024, Dir: dir}),
+		WithRetryOptions(&RetryOptions{MaxRetries: 1, DelayAfterRetry: time.Millisecond, ShouldRetryFunc: RetryIfNotSuccess}),
+	)
+
+	resp, err := client.POST(t.Context(), "", strings.NewReader(large), RequestOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{large, large}, received)
+
+	// the spooled request body is removed once the call is done
+	assert.Zero(t, spoolFiles(t, dir))
+}
+
+func TestClient_WithStreaming_CoalescedResponsesShareSpool(t *testing.T) {
+	large := strings.Repeat("c", 2048)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		<-release
+		_, _ = rw.Write([]byte(large))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	dir := t.TempDir()
+
+	client := NewClient(baseURL, nil, WithStreaming(StreamingOptions{Threshold: 1024, Dir: dir}), WithRequestCoalescing())
+
+	responses := make([]*http.Response, 2)
+	var wg sync.WaitGroup
+	for i := range responses {
+		wg.Go(func() {
+			resp, err := client.GET(t.Context(), "/object", RequestOptions{})
+			assert.NoError(t, err)
+			responses[i] = resp
+		})
+	}
+	require.Eventually(t, func() bool { return client.coalescer.waitersFor("/object") == 2 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, 1, spoolFiles(t, dir))
+	assertBody(t, large, responses[0])
+	require.NoError(t, responses[0].Body.Close())
+
+	assert.Equal(t, 1, spoolFiles(t, dir), "spool must be kept until all callers closed their body")
+	assertBody(t, large, responses[1])
+	require.NoError(t, responses[1].Body.Close())
+	assert.Zero(t, spoolFiles(t, dir))
+}
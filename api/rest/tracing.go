@@ -0,0 +1,273 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	traceparentHeader = "traceparent"
+
+	// Span attribute keys, following the OpenTelemetry semantic conventions where applicable.
+	AttributeHTTPMethod    = "http.request.method"
+	AttributeURL           = "url.full"
+	AttributeStatusCode    = "http.response.status_code"
+	AttributeResendCount   = "http.request.resend_count"
+	AttributeErrorType     = "error.type"
+	AttributeRateLimitWait = "dynatrace.rate_limit.wait"
+	AttributeCacheHit      = "dynatrace.cache.hit"
+	AttributeCoalesced     = "dynatrace.request.coalesced"
+)
+
+// SpanContext identifies a span within a trace, as defined by the W3C Trace Context specification.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// IsValid returns true if both TraceID and SpanID are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// Traceparent returns the value of the W3C "traceparent" header propagating this SpanContext.
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", hex.EncodeToString(sc.TraceID[:]), hex.EncodeToString(sc.SpanID[:]), flags)
+}
+
+// Span is a single traced unit of work, e.g. a logical operation or an HTTP request attempt.
+type Span interface {
+	// SetAttributes adds the given attributes to the span.
+	SetAttributes(attributes ...slog.Attr)
+	// RecordError marks the span as failed with the given error.
+	RecordError(err error)
+	// End completes the span.
+	End()
+	// SpanContext returns the identifiers of the span used for propagation.
+	SpanContext() SpanContext
+}
+
+// Tracer creates spans. To export spans using OpenTelemetry, use the Tracer of the oteltracer package, which adapts
+// an OpenTelemetry trace.Tracer. InMemoryTracer is intended for tests.
+type Tracer interface {
+	// Start creates a new span as child of the span contained in ctx, if any, and returns a context containing the new
+	// span. Implementations should use ContextWithSpan and SpanFromContext to store and find spans.
+	Start(ctx context.Context, name string, attributes ...slog.Attr) (context.Context, Span)
+}
+
+// WithTracer enables tracing of all HTTP requests of the Client. Each attempt to send a request is traced as its own
+// span, carrying the method, URL, status code and retry count as attributes, and its context is propagated to the
+// server via the W3C "traceparent" header.
+// Use Client.StartOperation to group multiple requests into a logical operation.
+func WithTracer(tracer Tracer) Option {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a copy of ctx containing the given Span.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the Span contained in ctx, or nil if there is none.
+func SpanFromContext(ctx context.Context) Span {
+	span, _ := ctx.Value(spanKey{}).(Span)
+	return span
+}
+
+// StartOperation starts a span for a logical operation consisting of one or more HTTP requests, e.g. an update which
+// first gets the current version of an object. Requests sent using the returned context are traced as its children.
+// The returned function ends the span, recording the given error if it is not nil.
+// The returned context also carries the logger of the Client with the name and attributes of the operation, see
+// LoggerFromContext. If no Tracer is configured for the Client, no span is started.
+func (c *Client) StartOperation(ctx context.Context, name string, attributes ...slog.Attr) (context.Context, func(err error)) {
+	ctx = withLogger(ctx, c.operationLogger(name, attributes))
+	if c.tracer == nil {
+		return ctx, func(error) {}
+	}
+
+	ctx, span := c.tracer.Start(ctx, name, attributes...)
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}
+}
+
+// TracingMiddleware returns a Middleware tracing each request it passes as a span created by the given Tracer and
+// propagating the span via the W3C "traceparent" header.
+func TracingMiddleware(tracer Tracer) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			// spans are exported to tracing backends, so they must not carry any secrets
+			redactor := RedactorFromContext(req.Context())
+			ctx, span := tracer.Start(req.Context(), "HTTP "+req.Method,
+				slog.String(AttributeHTTPMethod, req.Method),
+				slog.String(AttributeURL, redactor.URL(req.URL).String()),
+				slog.Int(AttributeResendCount, attemptFrom(req.Context())))
+			defer span.End()
+
+			if sc := span.SpanContext(); sc.IsValid() {
+				req.Header.Set(traceparentHeader, sc.Traceparent())
+			}
+
+			resp, err := next(req.WithContext(ctx))
+			if err != nil {
+				span.SetAttributes(slog.String(AttributeErrorType, fmt.Sprintf("%T", err)))
+				span.RecordError(redactError(redactor, err))
+				return resp, err
+			}
+
+			span.SetAttributes(slog.Int(AttributeStatusCode, resp.StatusCode))
+			if !IsSuccess(resp) {
+				span.SetAttributes(slog.String(AttributeErrorType, resp.Status))
+			}
+			return resp, nil
+		}
+	}
+}
+
+// addSpanAttributes adds the given attributes to the span contained in ctx, if any.
+func addSpanAttributes(ctx context.Context, attributes ...slog.Attr) {
+	if span := SpanFromContext(ctx); span != nil {
+		span.SetAttributes(attributes...)
+	}
+}
+
+// RecordedSpan is a span recorded by an InMemoryTracer.
+type RecordedSpan struct {
+	Name         string
+	SpanContext  SpanContext
+	ParentSpanID [8]byte
+	Start        time.Time
+	End          time.Time
+	Attributes   []slog.Attr
+	Errors       []error
+}
+
+// Attribute returns the value of the attribute with the given key and true, or false if the span has no such attribute.
+// If an attribute was set multiple times, the latest value is returned.
+func (s RecordedSpan) Attribute(key string) (slog.Value, bool) {
+	for i := len(s.Attributes) - 1; i >= 0; i-- {
+		if s.Attributes[i].Key == key {
+			return s.Attributes[i].Value, true
+		}
+	}
+	return slog.Value{}, false
+}
+
+// InMemoryTracer is a Tracer keeping all ended spans in memory. It is intended for tests and debugging.
+type InMemoryTracer struct {
+	mu    sync.Mutex
+	spans []RecordedSpan
+}
+
+// NewInMemoryTracer creates a new InMemoryTracer.
+func NewInMemoryTracer() *InMemoryTracer {
+	return &InMemoryTracer{}
+}
+
+// Start creates a new span as child of the span contained in ctx, if any.
+func (t *InMemoryTracer) Start(ctx context.Context, name string, attributes ...slog.Attr) (context.Context, Span) {
+	s := &inMemorySpan{
+		tracer: t,
+		span: RecordedSpan{
+			Name:       name,
+			Start:      time.Now(),
+			Attributes: attributes,
+		},
+	}
+
+	if parent := SpanFromContext(ctx); parent != nil && parent.SpanContext().IsValid() {
+		s.span.SpanContext.TraceID = parent.SpanContext().TraceID
+		s.span.ParentSpanID = parent.SpanContext().SpanID
+	} else {
+		_, _ = rand.Read(s.span.SpanContext.TraceID[:]) // crypto/rand.Read never returns an error
+	}
+	_, _ = rand.Read(s.span.SpanContext.SpanID[:])
+	s.span.SpanContext.Sampled = true
+
+	return ContextWithSpan(ctx, s), s
+}
+
+// Spans returns all spans ended so far, in the order they were ended.
+func (t *InMemoryTracer) Spans() []RecordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]RecordedSpan(nil), t.spans...)
+}
+
+// Reset removes all recorded spans.
+func (t *InMemoryTracer) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = nil
+}
+
+type inMemorySpan struct {
+	tracer *InMemoryTracer
+	mu     sync.Mutex
+	span   RecordedSpan
+	ended  bool
+}
+
+func (s *inMemorySpan) SetAttributes(attributes ...slog.Attr) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.span.Attributes = append(s.span.Attributes, attributes...)
+}
+
+func (s *inMemorySpan) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.span.Errors = append(s.span.Errors, err)
+}
+
+func (s *inMemorySpan) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.span.End = time.Now()
+	span := s.span
+	s.mu.Unlock()
+
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.tracer.spans = append(s.tracer.spans, span)
+}
+
+func (s *inMemorySpan) SpanContext() SpanContext {
+	return s.span.SpanContext
+}
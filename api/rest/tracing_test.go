@@ -0,0 +1,139 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpanContext_Traceparent(t *testing.T) {
+	sc := SpanContext{
+		TraceID: [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  [8]byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		Sampled: true,
+	}
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", sc.Traceparent())
+	assert.True(t, sc.IsValid())
+	assert.False(t, SpanContext{}.IsValid())
+}
+
+func TestClient_WithTracer(t *testing.T) {
+	var traceparents []string
+	apiHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		apiHits++
+		traceparents = append(traceparents, req.Header.Get(traceparentHeader))
+		if apiHits == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tracer := NewInMemoryTracer()
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, nil,
+		WithTracer(tracer),
+		WithRetryOptions(&RetryOptions{MaxRetries: 1, ShouldRetryFunc: RetryIfNotSuccess}),
+	)
+
+	ctx, end := client.StartOperation(t.Context(), "operation")
+	resp, err := client.GET(ctx, "", RequestOptions{})
+	end(err)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	spans := tracer.Spans()
+	require.Len(t, spans, 3)
+	first, second, operation := spans[0], spans[1], spans[2]
+
+	assert.Equal(t, "operation", operation.Name)
+	assert.Empty(t, operation.Errors)
+
+	for i, span := range []RecordedSpan{first, second} {
+		assert.Equal(t, "HTTP GET", span.Name)
+		assert.Equal(t, operation.SpanContext.TraceID, span.SpanContext.TraceID)
+		assert.Equal(t, operation.SpanContext.SpanID, span.ParentSpanID)
+		assert.Equal(t, span.SpanContext.Traceparent(), traceparents[i], "expected span to be propagated to server")
+
+		resendCount, ok := span.Attribute(AttributeResendCount)
+		require.True(t, ok)
+		assert.Equal(t, int64(i), resendCount.Int64())
+	}
+
+	status, _ := first.Attribute(AttributeStatusCode)
+	assert.Equal(t, int64(http.StatusServiceUnavailable), status.Int64())
+	_, ok := first.Attribute(AttributeErrorType)
+	assert.True(t, ok, "expected unsuccessful attempt to have an error type")
+
+	status, _ = second.Attribute(AttributeStatusCode)
+	assert.Equal(t, int64(http.StatusOK), status.Int64())
+	_, ok = second.Attribute(AttributeErrorType)
+	assert.False(t, ok)
+}
+
+func TestClient_WithTracer_RecordsTransportErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	baseURL, _ := url.Parse(server.URL)
+	server.Close()
+
+	tracer := NewInMemoryTracer()
+	client := NewClient(baseURL, nil, WithTracer(tracer))
+
+	_, err := client.GET(t.Context(), "", RequestOptions{})
+	require.Error(t, err)
+
+	spans := tracer.Spans()
+	require.Len(t, spans, 1)
+	assert.Len(t, spans[0].Errors, 1)
+	_, ok := spans[0].Attribute(AttributeErrorType)
+	assert.True(t, ok)
+}
+
+func TestClient_StartOperation_WithoutTracer(t *testing.T) {
+	client := NewClient(&url.URL{}, nil)
+	ctx, end := client.StartOperation(t.Context(), "operation")
+	end(errors.New("error"))
+	assert.Nil(t, SpanFromContext(ctx))
+}
+
+func TestClient_WithTracer_RedactsURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+
+	tracer := NewInMemoryTracer()
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(baseURL, nil, WithTracer(tracer))
+
+	_, err := client.GET(t.Context(), "objects", RequestOptions{QueryParams: url.Values{"token": {"secret-token"}, "a": {"b"}}})
+	require.NoError(t, err)
+
+	spans := tracer.Spans()
+	require.Len(t, spans, 1)
+	u, ok := spans[0].Attribute(AttributeURL)
+	require.True(t, ok)
+	assert.NotContains(t, u.String(), "secret-token")
+	assert.Contains(t, u.String(), "token="+url.QueryEscape(redact.Mask))
+	assert.Contains(t, u.String(), "a=b")
+}
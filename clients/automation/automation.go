@@ -22,9 +22,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
+	"log/slog"
 	"net/http"
 	"net/url"
-	"strconv"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
@@ -69,20 +70,9 @@ var (
 		BusinessCalendars: {Path: "/platform/automation/v1/business-calendars"},
 		SchedulingRules:   {Path: "/platform/automation/v1/scheduling-rules"},
 	}
-	ErrMissingID = errors.New("id must be non empty")
+	ErrMissingID = api.NewValidationSentinel("id must be non empty")
 )
 
-type listResponse struct {
-	Count   int               `json:"count"`
-	Objects []json.RawMessage `json:"results"`
-}
-
-type listNextResult struct {
-	api.ListResponse
-	Count         int
-	WfAdminAccess bool
-}
-
 // NewClient creates and returns a new instance a client which is used for interacting
 // with automation resources.
 //
@@ -120,7 +110,10 @@ type Client struct {
 //
 //   - Response: A Response containing the result of the HTTP operation, including status code and data.
 //   - error: An error if the HTTP call fails or another error happened.
-func (a Client) Get(ctx context.Context, resourceType ResourceType, id string) (api.Response, error) {
+func (a Client) Get(ctx context.Context, resourceType ResourceType, id string) (_ api.Response, err error) {
+	ctx, endOperation := a.restClient.StartOperation(ctx, "automation.Client.Get", slog.Any("resourceType", resourceType), slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
 		return api.Response{}, fmt.Errorf(errMsg, getOperation, resourceType, ErrMissingID)
 	}
@@ -137,7 +130,7 @@ func (a Client) Get(ctx context.Context, resourceType ResourceType, id string) (
 		return api.Response{}, fmt.Errorf(errMsgWithId, getOperation, resourceType, id, err)
 	}
 
-	resp, err := a.makeRequestWithAdminAccess(resourceType, func(options rest.RequestOptions) (*http.Response, error) {
+	resp, err := a.makeRequestWithAdminAccess(resourceType, resources[resourceType].Path+"/{id}", func(options rest.RequestOptions) (*http.Response, error) {
 		return a.restClient.GET(ctx, path, options)
 	})
 
@@ -160,8 +153,11 @@ func (a Client) Get(ctx context.Context, resourceType ResourceType, id string) (
 //
 //   - Response: A Response containing the result of the HTTP operation, including status code and data.
 //   - error: An error if the HTTP call fails or another error happened.
-func (a Client) Create(ctx context.Context, resourceType ResourceType, data []byte) (api.Response, error) {
-	resp, err := a.makeRequestWithAdminAccess(resourceType, func(options rest.RequestOptions) (*http.Response, error) {
+func (a Client) Create(ctx context.Context, resourceType ResourceType, data []byte) (_ api.Response, err error) {
+	ctx, endOperation := a.restClient.StartOperation(ctx, "automation.Client.Create", slog.Any("resourceType", resourceType))
+	defer func() { endOperation(err) }()
+
+	resp, err := a.makeRequestWithAdminAccess(resourceType, resources[resourceType].Path, func(options rest.RequestOptions) (*http.Response, error) {
 		return a.restClient.POST(ctx, resources[resourceType].Path, bytes.NewReader(data), options)
 	})
 	if err != nil {
@@ -184,7 +180,10 @@ func (a Client) Create(ctx context.Context, resourceType ResourceType, data []by
 //
 //   - Response: A Response containing the result of the HTTP operation, including status code and data.
 //   - error: An error if the HTTP call fails or another error happened.
-func (a Client) Update(ctx context.Context, resourceType ResourceType, id string, data []byte) (api.Response, error) {
+func (a Client) Update(ctx context.Context, resourceType ResourceType, id string, data []byte) (_ api.Response, err error) {
+	ctx, endOperation := a.restClient.StartOperation(ctx, "automation.Client.Update", slog.Any("resourceType", resourceType), slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if err := rmIDField(&data); err != nil {
 		return api.Response{}, fmt.Errorf(errMsgWithId, updateOperation, resourceType, id, fmt.Errorf("unable to remove id field from payload in order to update object: %w", err))
 	}
@@ -196,7 +195,7 @@ func (a Client) Update(ctx context.Context, resourceType ResourceType, id string
 		return api.Response{}, fmt.Errorf(errMsgWithId, updateOperation, resourceType, id, err)
 	}
 
-	resp, err := a.makeRequestWithAdminAccess(resourceType, func(options rest.RequestOptions) (*http.Response, error) {
+	resp, err := a.makeRequestWithAdminAccess(resourceType, resources[resourceType].Path+"/{id}", func(options rest.RequestOptions) (*http.Response, error) {
 		return a.restClient.PUT(ctx, path, bytes.NewReader(data), options)
 	})
 	if err != nil {
@@ -224,88 +223,75 @@ func (a Client) Update(ctx context.Context, resourceType ResourceType, id string
 //   - ListResponse: A ListResponse which is an api.PagedListResponse containing all objects fetched from the api
 //   - error: An error if the HTTP call fails or another error happened.
 func (a Client) List(ctx context.Context, resourceType ResourceType) (api.PagedListResponse, error) {
-	// retVal are the collected paginated API responses
-	var retVal api.PagedListResponse
-
-	// result is the latest API response received
-	totalCount := 1 // ensure starting condition is met, after the first API call this will be actual total count of objects
-	retrieved := 0
-
-	wfAdminAccess := resourceType == Workflows // only use admin access for workflows
-
-	for retrieved < totalCount {
-		if nextResult, err := a.listPage(ctx, resourceType, wfAdminAccess, retrieved); err != nil {
-			return api.PagedListResponse{}, err
-		} else {
-			if wfAdminAccess && !nextResult.WfAdminAccess {
-				wfAdminAccess = false
-				continue
-			}
-
-			totalCount = nextResult.Count
-			retrieved += len(nextResult.Objects)
-			retVal = append(retVal, nextResult.ListResponse)
-		}
+	retVal, err := api.CollectPages(a.pages(ctx, "automation.Client.List", resourceType))
+	if err != nil {
+		return api.PagedListResponse{}, err
 	}
-
 	return retVal, nil
 }
 
-func (a Client) listPage(ctx context.Context, resourceType ResourceType, wfAdminAccess bool, offset int) (listNextResult, error) {
-	opts := rest.RequestOptions{
-		QueryParams: url.Values{
-			"offset": []string{strconv.Itoa(offset)},
-		},
-	}
-	if wfAdminAccess {
-		opts.QueryParams["adminAccess"] = []string{"true"}
-	}
+// ListPages returns an iterator over the pages of automation objects of the specified resource. In contrast to List,
+// pages are fetched lazily while iterating, and no further pages are fetched once the iteration is stopped.
+func (a Client) ListPages(ctx context.Context, resourceType ResourceType) iter.Seq2[api.ListResponse, error] {
+	return a.pages(ctx, "automation.Client.ListPages", resourceType)
+}
 
-	resp, err := a.restClient.GET(ctx, resources[resourceType].Path, opts)
-	if err != nil {
-		return listNextResult{}, fmt.Errorf(errMsg, listOperation, resourceType, err)
-	}
-	res, err := api.NewResponseFromHTTPResponse(resp)
-	if err != nil {
-		var apiErr api.APIError
-		// if Workflow API rejected the initial request with admin permissions -> retry without
-		if wfAdminAccess && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden {
-			return listNextResult{WfAdminAccess: false}, nil
+// ListObjects returns an iterator over the automation objects of the specified resource, fetching pages lazily like
+// ListPages. Use api.DecodeObjects to iterate over typed objects.
+func (a Client) ListObjects(ctx context.Context, resourceType ResourceType) iter.Seq2[[]byte, error] {
+	return api.Objects(a.pages(ctx, "automation.Client.ListObjects", resourceType))
+}
+
+// listCursor is the position of the next page to list.
+type listCursor struct {
+	api.PageCursor
+	// withoutAdminAccess is set once the API rejected listing workflows with admin access
+	withoutAdminAccess bool
+}
+
+func (a Client) pages(ctx context.Context, operation string, resourceType ResourceType) iter.Seq2[api.ListResponse, error] {
+	return api.Pages(ctx, a.restClient, operation, func(ctx context.Context, cursor listCursor) (api.ListResponse, listCursor, bool, error) {
+		// only use admin access for workflows
+		if resourceType == Workflows && !cursor.withoutAdminAccess {
+			page, next, more, err := a.paginator(resourceType, true).Fetch(ctx, cursor.PageCursor)
+			var apiErr api.APIError
+			// if Workflow API rejected the request with admin permissions -> retry without
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+				return page, listCursor{PageCursor: next}, more, err
+			}
+			cursor.withoutAdminAccess = true
 		}
-		return listNextResult{}, err
-	}
 
-	result, err := unmarshalJSONList(res.Data)
-	if err != nil {
-		return listNextResult{}, api.NewAPIErrorFromResponseAndBody(resp, res.Data)
-	}
+		page, next, more, err := a.paginator(resourceType, false).Fetch(ctx, cursor.PageCursor)
+		return page, listCursor{PageCursor: next, withoutAdminAccess: cursor.withoutAdminAccess}, more, err
+	}, slog.Any("resourceType", resourceType))
+}
 
-	b := make([][]byte, len(result.Objects))
-	for i, v := range result.Objects {
-		b[i], _ = v.MarshalJSON() // marshalling the JSON back to JSON will not fail
+// paginator returns the api.Paginator listing the objects of the given resource type. The API returns the total
+// number of objects as "count" and the objects of a page as "results".
+func (a Client) paginator(resourceType ResourceType, wfAdminAccess bool) api.Paginator {
+	opts := rest.RequestOptions{Endpoint: resources[resourceType].Path}
+	if wfAdminAccess {
+		opts.QueryParams = url.Values{"adminAccess": []string{"true"}}
 	}
-
-	return listNextResult{
-		api.ListResponse{
-			Response: api.Response{
-				StatusCode: resp.StatusCode,
-				Data:       res.Data,
-				Request: rest.RequestInfo{
-					Method: resp.Request.Method,
-					URL:    resp.Request.URL.String(),
-				},
-			},
-			Objects: b,
+	return api.Paginator{
+		Client:       a.restClient,
+		Strategy:     api.OffsetStrategy("count"),
+		Path:         resources[resourceType].Path,
+		Options:      opts,
+		ObjectsField: "results",
+		Resource:     resources[resourceType].Path,
+		WrapError: func(err error) error {
+			return fmt.Errorf(errMsg, listOperation, resourceType, err)
 		},
-		result.Count,
-		wfAdminAccess,
-	}, nil
+	}
 }
 
-func (a Client) makeRequestWithAdminAccess(resourceType ResourceType, request func(options rest.RequestOptions) (*http.Response, error)) (*http.Response, error) {
+func (a Client) makeRequestWithAdminAccess(resourceType ResourceType, endpoint string, request func(options rest.RequestOptions) (*http.Response, error)) (*http.Response, error) {
 	if resourceType == Workflows {
 		opts := rest.RequestOptions{
 			QueryParams: url.Values{"adminAccess": []string{"true"}},
+			Endpoint:    endpoint,
 		}
 		resp, err := request(opts)
 		if err != nil {
@@ -313,12 +299,12 @@ func (a Client) makeRequestWithAdminAccess(resourceType ResourceType, request fu
 		}
 		if resp != nil && resp.StatusCode == http.StatusForbidden {
 			resp.Body.Close()
-			return request(rest.RequestOptions{})
+			return request(rest.RequestOptions{Endpoint: endpoint})
 		}
 		return resp, err
 	}
 
-	return request(rest.RequestOptions{})
+	return request(rest.RequestOptions{Endpoint: endpoint})
 }
 
 // Delete removes an automation object of the specified resource type by its unique identifier (ID).
@@ -335,7 +321,10 @@ func (a Client) makeRequestWithAdminAccess(resourceType ResourceType, request fu
 //
 //   - Response: A Response containing the result of the HTTP operation, including status code and data.
 //   - error: An error if the HTTP call fails or another error happened.
-func (a Client) Delete(ctx context.Context, resourceType ResourceType, id string) (api.Response, error) {
+func (a Client) Delete(ctx context.Context, resourceType ResourceType, id string) (_ api.Response, err error) {
+	ctx, endOperation := a.restClient.StartOperation(ctx, "automation.Client.Delete", slog.Any("resourceType", resourceType), slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
 		return api.Response{}, fmt.Errorf(errMsg, deleteOperation, resourceType, ErrMissingID)
 	}
@@ -344,7 +333,7 @@ func (a Client) Delete(ctx context.Context, resourceType ResourceType, id string
 		return api.Response{}, fmt.Errorf(errMsgWithId, deleteOperation, resourceType, id, err)
 	}
 
-	resp, err := a.makeRequestWithAdminAccess(resourceType, func(options rest.RequestOptions) (*http.Response, error) {
+	resp, err := a.makeRequestWithAdminAccess(resourceType, resources[resourceType].Path+"/{id}", func(options rest.RequestOptions) (*http.Response, error) {
 		return a.restClient.DELETE(ctx, path, options)
 	})
 
@@ -355,15 +344,6 @@ func (a Client) Delete(ctx context.Context, resourceType ResourceType, id string
 	return api.NewResponseFromHTTPResponse(resp)
 }
 
-func unmarshalJSONList(body []byte) (listResponse, error) {
-	var r listResponse
-	err := json.Unmarshal(body, &r)
-	if err != nil {
-		return listResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-	return r, nil
-}
-
 func rmIDField(data *[]byte) error {
 	var m map[string]any
 	err := json.Unmarshal(*data, &m)
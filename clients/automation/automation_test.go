@@ -631,6 +631,85 @@ func TestAutomationClient_List(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("ListObjects - fetches pages lazily", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					return testutils.Response{
+						ResponseCode: http.StatusForbidden,
+						ResponseBody: "{}",
+					}
+				},
+				ValidateRequest: func(t *testing.T, req *http.Request) {
+					assert.Equal(t, []string{"true"}, req.URL.Query()["adminAccess"])
+				},
+			},
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					return testutils.Response{
+						ResponseCode: http.StatusOK,
+						ResponseBody: `{ "count": 3,"results": [ {"id": "1"}, {"id": "2"} ] }`,
+					}
+				},
+				ValidateRequest: func(t *testing.T, req *http.Request) {
+					assert.Nil(t, req.URL.Query()["adminAccess"])
+				},
+			},
+		}
+
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		client := automation.NewClient(rest.NewClient(server.URL(), server.Client()))
+
+		var ids []string
+		for o, err := range api.DecodeObjects[struct{ ID string }](client.ListObjects(t.Context(), automation.Workflows)) {
+			assert.NoError(t, err)
+			ids = append(ids, o.ID)
+			if len(ids) == 2 {
+				break
+			}
+		}
+		assert.Equal(t, []string{"1", "2"}, ids, "the second page must not be fetched")
+	})
+
+	t.Run("ListPages - OK", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					return testutils.Response{
+						ResponseCode: http.StatusOK,
+						ResponseBody: `{ "count": 2,"results": [ {"id": "1"} ] }`,
+					}
+				},
+			},
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					return testutils.Response{
+						ResponseCode: http.StatusOK,
+						ResponseBody: `{ "count": 2,"results": [ {"id": "2"} ] }`,
+					}
+				},
+				ValidateRequest: func(t *testing.T, req *http.Request) {
+					assert.Equal(t, []string{"1"}, req.URL.Query()["offset"])
+				},
+			},
+		}
+
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		client := automation.NewClient(rest.NewClient(server.URL(), server.Client()))
+
+		var pages int
+		for page, err := range client.ListPages(t.Context(), automation.SchedulingRules) {
+			assert.NoError(t, err)
+			assert.Len(t, page.Objects, 1)
+			pages++
+		}
+		assert.Equal(t, 2, pages)
+	})
+
 	t.Run("List - Paginated - Getting one page fails", func(t *testing.T) {
 
 		responses := []testutils.ResponseDef{
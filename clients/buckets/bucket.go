@@ -20,17 +20,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"net/url"
 	"strconv"
 
-	"github.com/google/go-cmp/cmp"
-
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/jsondiff"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 )
 
 const (
 	endpointPath    = "platform/storage/management/v1/bucket-definitions"
+	bucketEndpoint  = "/" + endpointPath + "/{bucketName}"
 	errUnmarshalMsg = "failed to unmarshal JSON response: %w"
 	errMsg          = "failed to %s bucket: %w"
 	errMsgWithName  = "failed to %s bucket with name %s: %w"
@@ -42,9 +43,13 @@ const (
 )
 
 var (
-	ErrBucketEmpty = fmt.Errorf("bucketName must be non-empty")
+	ErrBucketEmpty = api.NewValidationSentinel("bucketName must be non-empty")
 )
 
+// noCacheHeaders are sent with every request, as bucket definitions change asynchronously and must not be served from
+// intermediate caches.
+var noCacheHeaders = http.Header{"Cache-Control": {"no-cache"}}
+
 type bucketResponse struct {
 	BucketName string `json:"bucketName"`
 	Status     string `json:"status"`
@@ -55,10 +60,6 @@ type bucketResponse struct {
 // For convenience, it contains a slice of Buckets in addition to the base api.Response data.
 type ListResponse = api.PagedListResponse
 
-type listResponse struct {
-	Buckets []json.RawMessage `json:"buckets"`
-}
-
 type Client struct {
 	restClient *rest.Client
 }
@@ -77,7 +78,6 @@ type Option func(*Client)
 // Returns:
 //   - *Client: A pointer to a new Client instance initialized with the provided rest.Client and logger.
 func NewClient(client *rest.Client, option ...Option) *Client {
-	client.SetHeader("Cache-Control", "no-cache")
 	c := &Client{
 		restClient: client,
 	}
@@ -89,14 +89,17 @@ func NewClient(client *rest.Client, option ...Option) *Client {
 	return c
 }
 
+// logger returns the logger to use within the given context, see rest.Client.Logger.
+func (c Client) logger(ctx context.Context) *slog.Logger {
+	return c.restClient.Logger(ctx)
+}
+
 // Get retrieves a bucket definition based on the provided bucketName. The function sends a GET request
 // to the server using the given context and bucketName. It returns a Response and an error indicating
 // the success or failure its execution.
 //
 // If the HTTP request to the server fails, the method returns an empty Response and an error explaining the issue.
 //
-// If you wish to receive logs from this method supply a logger inside the context using logr.NewContext.
-//
 // Parameters:
 //   - ctx: Context for controlling the HTTP operation's lifecycle.
 //   - bucketName: The name of the bucket to be retrieved.
@@ -104,13 +107,16 @@ func NewClient(client *rest.Client, option ...Option) *Client {
 // Returns:
 //   - Response: A Response containing the result of the HTTP call, including status code and data.
 //   - error: An error if the HTTP call fails or another error happened.
-func (c Client) Get(ctx context.Context, bucketName string) (api.Response, error) {
+func (c Client) Get(ctx context.Context, bucketName string) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "buckets.Client.Get", slog.String("bucketName", bucketName))
+	defer func() { endOperation(err) }()
+
 	path, err := url.JoinPath(endpointPath, bucketName)
 	if err != nil {
 		return api.Response{}, fmt.Errorf(errMsgWithName, getOperation, bucketName, err)
 	}
 
-	resp, err := c.restClient.GET(ctx, path, rest.RequestOptions{})
+	resp, err := c.restClient.GET(ctx, path, rest.RequestOptions{Endpoint: bucketEndpoint, Headers: noCacheHeaders})
 	if err != nil {
 		return api.Response{}, fmt.Errorf(errMsgWithName, getOperation, bucketName, err)
 	}
@@ -124,42 +130,28 @@ func (c Client) Get(ctx context.Context, bucketName string) (api.Response, error
 //
 // If the HTTP request to the server fails, the method returns an empty slice and an error explaining the issue.
 //
-// If you wish to receive logs from this method supply a logger inside the context using logr.NewContext.
-//
 // Parameters:
-//   - ctx: Context for controlling the HTTP operation's lifecycle. Possibly containing a logger created with logr.NewContext.
+//   - ctx: Context for controlling the HTTP operation's lifecycle.
 //
 // Returns:
 //   - []Response: A slice of bucket Response containing the individual buckets resulting from the HTTP call, including status code and data.
 //   - error: An error if the HTTP call fails or another error happened.
 func (c Client) List(ctx context.Context) (ListResponse, error) {
-	resp, err := c.restClient.GET(ctx, endpointPath, rest.RequestOptions{})
-	if err != nil {
-		return ListResponse{}, fmt.Errorf(errMsg, listOperation, err)
+	paginator := api.Paginator{
+		Client:       c.restClient,
+		Strategy:     api.SinglePagePagination{},
+		Path:         endpointPath,
+		Options:      rest.RequestOptions{Headers: noCacheHeaders},
+		ObjectsField: "buckets",
+		Resource:     "buckets",
+		WrapError:    func(err error) error { return fmt.Errorf(errMsg, listOperation, err) },
 	}
 
-	apiResp, err := api.NewResponseFromHTTPResponse(resp)
+	pages, err := api.CollectPages(paginator.Pages(ctx, "buckets.Client.List"))
 	if err != nil {
 		return ListResponse{}, err
 	}
-	r := listResponse{}
-	err = json.Unmarshal(apiResp.Data, &r)
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to unmarshal JSON response", slog.String("error", err.Error()))
-		return ListResponse{}, fmt.Errorf(errMsg, listOperation, fmt.Errorf(errUnmarshalMsg, err))
-	}
-
-	b := make([][]byte, len(r.Buckets))
-	for i, v := range r.Buckets {
-		b[i], _ = v.MarshalJSON() // marshalling the JSON back to JSON will not fail
-	}
-
-	return ListResponse{
-		api.ListResponse{
-			Response: apiResp,
-			Objects:  b,
-		},
-	}, nil
+	return ListResponse(pages), nil
 }
 
 // Create sends a request to the server to create a new bucket with the provided bucketName and data.
@@ -169,21 +161,22 @@ func (c Client) List(ctx context.Context) (ListResponse, error) {
 // If setting the bucket name in the data encounters an error, or if the HTTP request to the server
 // fails, the function returns an empty Response and an error explaining the issue.
 //
-// If you wish to receive logs from this method supply a logger inside the context using logr.NewContext.
-//
 // Parameters:
-//   - ctx: Context for controlling the HTTP operation's lifecycle. Possibly containing a logger created with logr.NewContext.
+//   - ctx: Context for controlling the HTTP operation's lifecycle.
 //   - bucketName: The name of the bucket to be created.
 //   - data: The data containing information about the new bucket.
 //
 // Returns:
 //   - Response: A Response containing the result of the HTTP call, including status code and data.
 //   - error: An error if the HTTP call fails or another error happened.
-func (c Client) Create(ctx context.Context, bucketName string, data []byte) (api.Response, error) {
+func (c Client) Create(ctx context.Context, bucketName string, data []byte) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "buckets.Client.Create", slog.String("bucketName", bucketName))
+	defer func() { endOperation(err) }()
+
 	if err := setBucketName(bucketName, &data); err != nil {
 		return api.Response{}, fmt.Errorf(errMsgWithName, createOperation, bucketName, fmt.Errorf("unable to set bucket name: %w", err))
 	}
-	r, err := c.restClient.POST(ctx, endpointPath, bytes.NewReader(data), rest.RequestOptions{})
+	r, err := c.restClient.POST(ctx, endpointPath, bytes.NewReader(data), rest.RequestOptions{Headers: noCacheHeaders})
 	if err != nil {
 		return api.Response{}, fmt.Errorf(errMsgWithName, createOperation, bucketName, err)
 	}
@@ -197,16 +190,17 @@ func (c Client) Create(ctx context.Context, bucketName string, data []byte) (api
 // If the provided bucketName is empty, the function returns an error indicating that the bucketName must be non-empty.
 // If the HTTP request to the server fails, the method returns an empty Response and an error explaining the issue.
 //
-// If you wish to receive logs from this method supply a logger inside the context using logr.NewContext.
-//
 // Parameters:
-//   - ctx: Context for controlling the deletion operation's lifecycle. Possibly containing a logger created with logr.NewContext.
+//   - ctx: Context for controlling the deletion operation's lifecycle.
 //   - bucketName: The name of the bucket to be deleted.
 //
 // Returns:
 //   - Response: A Response containing the result of the HTTP call, including status code and data.
 //   - error: An error if the HTTP call fails or another error happened.
-func (c Client) Delete(ctx context.Context, bucketName string) (api.Response, error) {
+func (c Client) Delete(ctx context.Context, bucketName string) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "buckets.Client.Delete", slog.String("bucketName", bucketName))
+	defer func() { endOperation(err) }()
+
 	if bucketName == "" {
 		return api.Response{}, fmt.Errorf(errMsg, deleteOperation, ErrBucketEmpty)
 	}
@@ -215,7 +209,7 @@ func (c Client) Delete(ctx context.Context, bucketName string) (api.Response, er
 		return api.Response{}, fmt.Errorf(errMsgWithName, deleteOperation, bucketName, err)
 	}
 
-	resp, err := c.restClient.DELETE(ctx, path, rest.RequestOptions{})
+	resp, err := c.restClient.DELETE(ctx, path, rest.RequestOptions{Endpoint: bucketEndpoint, Headers: noCacheHeaders})
 
 	if err != nil {
 		return api.Response{}, fmt.Errorf(errMsgWithName, deleteOperation, bucketName, err)
@@ -238,17 +232,18 @@ func (c Client) Delete(ctx context.Context, bucketName string) (api.Response, er
 // Update will not make an HTTP call, as this would needlessly increase the buckets version.
 // This is transparent to callers and a normal StatusCode 200 Response is returned.
 //
-// If you wish to receive logs from this method supply a logger inside the context using logr.NewContext.
-//
 // Parameters:
-//   - ctx: Context for controlling the HTTP operation's lifecycle. Possibly containing a logger created with logr.NewContext.
+//   - ctx: Context for controlling the HTTP operation's lifecycle.
 //   - bucketName: The name of the bucket to be updated.
 //   - data: The new data to be assigned to the bucket.
 //
 // Returns:
 //   - Response: A Response containing the result of the HTTP operation, including status code and data.
 //   - error: An error if the HTTP call fails or another error happened.
-func (c Client) Update(ctx context.Context, bucketName string, data []byte) (api.Response, error) {
+func (c Client) Update(ctx context.Context, bucketName string, data []byte) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "buckets.Client.Update", slog.String("bucketName", bucketName))
+	defer func() { endOperation(err) }()
+
 	// try to get existing bucket definition
 	apiResp, err := c.Get(ctx, bucketName)
 	if err != nil {
@@ -262,7 +257,7 @@ func (c Client) Update(ctx context.Context, bucketName string, data []byte) (api
 	}
 
 	if bucketsEqual(apiResp.Data, data) {
-		slog.DebugContext(ctx, "Configuration unmodified, no need to update bucket", slog.String("bucketName", bucketName))
+		c.restClient.Logger(ctx).DebugContext(ctx, "Configuration unmodified, no need to update bucket", slog.String("bucketName", bucketName))
 
 		return api.Response{
 			StatusCode: 200,
@@ -290,6 +285,8 @@ func (c Client) Update(ctx context.Context, bucketName string, data []byte) (api
 	}
 
 	resp, err := c.restClient.PUT(ctx, path, bytes.NewReader(data), rest.RequestOptions{
+		Endpoint:    bucketEndpoint,
+		Headers:     noCacheHeaders,
 		QueryParams: url.Values{"optimistic-locking-version": []string{strconv.Itoa(res.Version)}},
 	})
 
@@ -304,21 +301,8 @@ func (c Client) Update(ctx context.Context, bucketName string, data []byte) (api
 // this means that like for an update bucketName, version and status are assumed to be
 // those of the existing object, ignoring what ever may be defined in the supplied data.
 func bucketsEqual(exists, new []byte) bool {
-	var existsMap map[string]any
-	if err := json.Unmarshal(exists, &existsMap); err != nil {
-		return false
-	}
-
-	var newMap map[string]any
-	if err := json.Unmarshal(new, &newMap); err != nil {
-		return false
-	}
-	// version and status are always taken from existing bucket on update
-	newMap["bucketName"] = existsMap["bucketName"]
-	newMap["version"] = existsMap["version"]
-	newMap["status"] = existsMap["status"]
-
-	return cmp.Equal(existsMap, newMap)
+	equal, err := jsondiff.Equal(exists, new, jsondiff.Options{IgnorePaths: []string{"/bucketName", "/version", "/status"}})
+	return err == nil && equal
 }
 
 // setBucketName sets the bucket name in the provided JSON data.
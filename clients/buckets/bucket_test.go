@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
@@ -96,6 +97,42 @@ func TestGet(t *testing.T) {
 		assert.Equal(t, resp.Data, []byte(activeBucketResponse))
 	})
 
+	t.Run("sends no-cache header without changing the shared rest client", func(t *testing.T) {
+
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, request *http.Request) testutils.Response {
+					assert.Equal(t, "no-cache", request.Header.Get("Cache-Control"))
+					return testutils.Response{
+						ResponseCode: http.StatusOK,
+						ResponseBody: activeBucketResponse,
+					}
+				},
+			},
+			{
+				GET: func(t *testing.T, request *http.Request) testutils.Response {
+					assert.Empty(t, request.Header.Get("Cache-Control"))
+					return testutils.Response{
+						ResponseCode: http.StatusOK,
+						ResponseBody: "{}",
+					}
+				},
+			},
+		}
+
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		restClient := rest.NewClient(server.URL(), server.Client())
+		client := buckets.NewClient(restClient)
+
+		_, err := client.Get(t.Context(), "bucket name")
+		assert.NoError(t, err)
+
+		_, err = restClient.GET(t.Context(), "other", rest.RequestOptions{})
+		assert.NoError(t, err)
+	})
+
 	t.Run("returns an error in case of a server issue", func(t *testing.T) {
 
 		responses := []testutils.ResponseDef{
@@ -457,6 +494,35 @@ func TestUpdate(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, apiError.StatusCode)
 	})
 
+	t.Run("dry run sends GET only", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, request *http.Request) testutils.Response {
+					return testutils.Response{
+						ResponseCode: http.StatusOK,
+						ResponseBody: activeBucketResponse,
+					}
+				},
+			},
+		}
+
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		restClient := rest.NewClient(server.URL(), server.Client(), rest.WithDryRun(rest.DryRunOptions{}))
+		client := buckets.NewClient(restClient)
+
+		resp, err := client.Update(t.Context(), "bucket name", []byte(`{"displayName": "changed"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		plan := restClient.DryRunPlan().Requests()
+		require.Len(t, plan, 1)
+		assert.Equal(t, http.MethodPut, plan[0].Method)
+		assert.Contains(t, string(plan[0].Body), `"displayName":"changed"`)
+		assert.Equal(t, 1, server.Calls())
+	})
+
 	t.Run("update bucket - OK", func(t *testing.T) {
 		responses := []testutils.ResponseDef{
 			{
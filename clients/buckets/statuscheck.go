@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 )
 
 type StatusClient interface {
@@ -61,7 +62,7 @@ func AwaitActiveOrNotFound(ctx context.Context, client StatusClient, bucketName
 				if !errors.Is(err, &apiErr) {
 					return false, err
 				}
-				sleep(ctx, bucketName, durationBetweenTries)
+				sleep(ctx, client, bucketName, durationBetweenTries)
 				continue
 			}
 			// try to unmarshal into internal struct
@@ -73,12 +74,25 @@ func AwaitActiveOrNotFound(ctx context.Context, client StatusClient, bucketName
 			if res.Status == stateActive {
 				return true, nil
 			}
-			sleep(ctx, bucketName, durationBetweenTries)
+			sleep(ctx, client, bucketName, durationBetweenTries)
 		}
 	}
 }
 
-func sleep(ctx context.Context, bucketName string, durationBetweenTries time.Duration) {
-	slog.DebugContext(ctx, "Waiting for bucket to become stable", slog.String("bucketName", bucketName))
+func sleep(ctx context.Context, client StatusClient, bucketName string, durationBetweenTries time.Duration) {
+	logger(ctx, client).DebugContext(ctx, "Waiting for bucket to become stable", slog.String("bucketName", bucketName))
 	time.Sleep(durationBetweenTries)
 }
+
+// loggingClient is implemented by Client, see Client.logger.
+type loggingClient interface {
+	logger(context.Context) *slog.Logger
+}
+
+// logger returns the logger of the given client if it is a Client, or the logger of ctx otherwise.
+func logger(ctx context.Context, client StatusClient) *slog.Logger {
+	if c, ok := client.(loggingClient); ok {
+		return c.logger(ctx)
+	}
+	return rest.LoggerFromContext(ctx)
+}
@@ -15,9 +15,11 @@
 package buckets_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"log/slog"
 	"net/http"
 	"testing"
 	"time"
@@ -25,7 +27,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/buckets"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/testutils"
 )
 
 type Client struct {
@@ -122,3 +126,28 @@ func TestAwaitBucketStable_ErrorsInvalidResponseData(t *testing.T) {
 	assert.ErrorAs(t, err, &wantErr)
 	assert.False(t, exists)
 }
+
+func TestAwaitBucketStable_LogsUsingLoggerOfClient(t *testing.T) {
+	server := testutils.NewHTTPTestServer(t, []testutils.ResponseDef{
+		{
+			GET: func(t *testing.T, req *http.Request) testutils.Response {
+				return testutils.Response{ResponseCode: http.StatusOK, ResponseBody: creatingBucketResponse}
+			},
+		},
+		{
+			GET: func(t *testing.T, req *http.Request) testutils.Response {
+				return testutils.Response{ResponseCode: http.StatusOK, ResponseBody: activeBucketResponse}
+			},
+		},
+	})
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := buckets.NewClient(rest.NewClient(server.URL(), server.Client(), rest.WithLogger(logger)))
+
+	exists, err := buckets.AwaitActiveOrNotFound(t.Context(), client, "my-bucket", time.Minute, time.Duration(0))
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Contains(t, buf.String(), "Waiting for bucket to become stable")
+}
@@ -17,7 +17,8 @@ package directshares
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"iter"
+	"log/slog"
 	"net/http"
 	"net/url"
 
@@ -27,6 +28,8 @@ import (
 
 const (
 	directSharesResourcePath = "/platform/document/v1/direct-shares"
+	directShareEndpoint      = directSharesResourcePath + "/{id}"
+	recipientsEndpoint       = directShareEndpoint + "/recipients"
 	resource                 = "direct-shares"
 )
 
@@ -41,78 +44,42 @@ func NewClient(client *rest.Client) *Client {
 	return &Client{restClient: client}
 }
 
-// List returns all direct share objects.
+// List returns all direct share objects, fetching all pages before returning. See ListPages to fetch pages lazily.
 func (c Client) List(ctx context.Context) (api.PagedListResponse, error) {
-	var pagedListResponse api.PagedListResponse
-
-	nextPageKey := ""
-	for {
-		var listResponse api.ListResponse
-		var err error
-
-		nextPageKey, listResponse, err = c.listPage(ctx, nextPageKey)
-		if err != nil {
-			return nil, err
-		}
-
-		pagedListResponse = append(pagedListResponse, listResponse)
-		if nextPageKey == "" {
-			break
-		}
-	}
-
-	return pagedListResponse, nil
+	return api.CollectPages(c.pages(ctx, "directshares.Client.List"))
 }
 
-func (c Client) listPage(ctx context.Context, pageKey string) (string, api.ListResponse, error) {
-	ro := rest.RequestOptions{}
-	if pageKey != "" {
-		ro.QueryParams = url.Values{"page-key": {pageKey}}
-	}
-
-	resp, err := c.restClient.GET(ctx, directSharesResourcePath, ro)
-	if err != nil {
-		return "", api.ListResponse{}, api.ClientError{Resource: resource, Operation: http.MethodGet, Wrapped: err}
-	}
-
-	return processListResponse(resp)
+// ListPages returns an iterator over the pages of all direct share objects. Pages are fetched lazily while iterating,
+// and no further pages are fetched once the iteration is stopped.
+func (c Client) ListPages(ctx context.Context) iter.Seq2[api.ListResponse, error] {
+	return c.pages(ctx, "directshares.Client.ListPages")
 }
 
-func processListResponse(httpResponse *http.Response) (string, api.ListResponse, error) {
-	resp, err := api.NewResponseFromHTTPResponse(httpResponse)
-	if err != nil {
-		return "", api.ListResponse{}, api.ClientError{Resource: resource, Operation: http.MethodGet, Wrapped: err}
-	}
-
-	var directSharesResponse struct {
-		NextPage     string            `json:"nextPageKey"`
-		DirectShares []json.RawMessage `json:"direct-shares"`
-	}
-
-	if err := json.Unmarshal(resp.Data, &directSharesResponse); err != nil {
-		return "", api.ListResponse{}, api.RuntimeError{Resource: resource, Reason: "unmarshalling failed", Wrapped: err}
-	}
-
-	var objects [][]byte
-	for _, it := range directSharesResponse.DirectShares {
-		objects = append(objects, it)
-	}
+// ListObjects returns an iterator over all direct share objects, fetching pages lazily like ListPages. Use
+// api.DecodeObjects to iterate over typed objects.
+func (c Client) ListObjects(ctx context.Context) iter.Seq2[[]byte, error] {
+	return api.Objects(c.pages(ctx, "directshares.Client.ListObjects"))
+}
 
-	return directSharesResponse.NextPage,
-		api.ListResponse{
-			Response: api.Response{
-				StatusCode: httpResponse.StatusCode,
-				Header:     httpResponse.Header,
-				Data:       nil,
-				Request:    api.NewRequestInfoFromRequest(httpResponse.Request),
-			},
-			Objects: objects,
+func (c Client) pages(ctx context.Context, operation string) iter.Seq2[api.ListResponse, error] {
+	paginator := api.Paginator{
+		Client:       c.restClient,
+		Strategy:     api.PageKeyStrategy(),
+		Path:         directSharesResourcePath,
+		ObjectsField: "direct-shares",
+		Resource:     resource,
+		WrapError: func(err error) error {
+			return api.ClientError{Resource: resource, Operation: http.MethodGet, Wrapped: err}
 		},
-		nil
+	}
+	return paginator.Pages(ctx, operation)
 }
 
 // Get returns one specific direct share object by ID.
-func (c Client) Get(ctx context.Context, id string) (api.Response, error) {
+func (c Client) Get(ctx context.Context, id string) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "directshares.Client.Get", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
 		return api.Response{}, idValidationErr
 	}
@@ -122,7 +89,7 @@ func (c Client) Get(ctx context.Context, id string) (api.Response, error) {
 		return api.Response{}, api.RuntimeError{Resource: resource, Identifier: id, Reason: "failed to construct URL", Wrapped: err}
 	}
 
-	httpResp, err := c.restClient.GET(ctx, path, rest.RequestOptions{})
+	httpResp, err := c.restClient.GET(ctx, path, rest.RequestOptions{Endpoint: directShareEndpoint})
 	if err != nil {
 		return api.Response{}, api.ClientError{Resource: resource, Identifier: id, Operation: http.MethodGet, Wrapped: err}
 	}
@@ -140,81 +107,30 @@ func (c Client) GetRecipients(ctx context.Context, id string) (api.PagedListResp
 		return nil, idValidationErr
 	}
 
-	var pagedListResponse api.PagedListResponse
-
-	nextPageKey := ""
-	for {
-		var listResponse api.ListResponse
-		var err error
-
-		nextPageKey, listResponse, err = c.listRecipientsPage(ctx, id, nextPageKey)
-		if err != nil {
-			return nil, err
-		}
-
-		pagedListResponse = append(pagedListResponse, listResponse)
-		if nextPageKey == "" {
-			break
-		}
-	}
-
-	return pagedListResponse, nil
-}
-
-func (c Client) listRecipientsPage(ctx context.Context, id string, pageKey string) (string, api.ListResponse, error) {
-	ro := rest.RequestOptions{}
-	if pageKey != "" {
-		ro.QueryParams = url.Values{"page-key": {pageKey}}
-	}
-
 	path, err := url.JoinPath(directSharesResourcePath, id, "recipients")
 	if err != nil {
-		return "", api.ListResponse{}, api.RuntimeError{Resource: resource, Identifier: id, Reason: "failed to construct URL", Wrapped: err}
-	}
-
-	httpResp, err := c.restClient.GET(ctx, path, ro)
-	if err != nil {
-		return "", api.ListResponse{}, api.ClientError{Resource: resource, Identifier: id, Operation: http.MethodGet, Wrapped: err}
-	}
-
-	return processRecipientsListResponse(httpResp)
-}
-
-func processRecipientsListResponse(httpResponse *http.Response) (string, api.ListResponse, error) {
-	resp, err := api.NewResponseFromHTTPResponse(httpResponse)
-	if err != nil {
-		return "", api.ListResponse{}, api.ClientError{Resource: resource, Operation: http.MethodGet, Wrapped: err}
+		return nil, api.RuntimeError{Resource: resource, Identifier: id, Reason: "failed to construct URL", Wrapped: err}
 	}
 
-	var recipientsResponse struct {
-		NextPage   string            `json:"nextPageKey"`
-		Recipients []json.RawMessage `json:"recipients"`
-	}
-
-	if err := json.Unmarshal(resp.Data, &recipientsResponse); err != nil {
-		return "", api.ListResponse{}, api.RuntimeError{Resource: resource, Reason: "unmarshalling failed", Wrapped: err}
-	}
-
-	var objects [][]byte
-	for _, it := range recipientsResponse.Recipients {
-		objects = append(objects, it)
-	}
-
-	return recipientsResponse.NextPage,
-		api.ListResponse{
-			Response: api.Response{
-				StatusCode: httpResponse.StatusCode,
-				Header:     httpResponse.Header,
-				Data:       nil,
-				Request:    api.NewRequestInfoFromRequest(httpResponse.Request),
-			},
-			Objects: objects,
+	paginator := api.Paginator{
+		Client:       c.restClient,
+		Strategy:     api.PageKeyStrategy(),
+		Path:         path,
+		Options:      rest.RequestOptions{Endpoint: recipientsEndpoint},
+		ObjectsField: "recipients",
+		Resource:     resource,
+		WrapError: func(err error) error {
+			return api.ClientError{Resource: resource, Identifier: id, Operation: http.MethodGet, Wrapped: err}
 		},
-		nil
+	}
+	return api.CollectPages(paginator.Pages(ctx, "directshares.Client.GetRecipients", slog.String("id", id)))
 }
 
 // AddRecipients adds recipients to a specific direct share.
-func (c Client) AddRecipients(ctx context.Context, id string, data []byte) error {
+func (c Client) AddRecipients(ctx context.Context, id string, data []byte) (err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "directshares.Client.AddRecipients", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
 		return idValidationErr
 	}
@@ -224,7 +140,7 @@ func (c Client) AddRecipients(ctx context.Context, id string, data []byte) error
 		return api.RuntimeError{Resource: resource, Identifier: id, Reason: "failed to construct URL", Wrapped: err}
 	}
 
-	httpResp, err := c.restClient.POST(ctx, path, bytes.NewReader(data), rest.RequestOptions{})
+	httpResp, err := c.restClient.POST(ctx, path, bytes.NewReader(data), rest.RequestOptions{Endpoint: recipientsEndpoint + "/add"})
 	if err != nil {
 		return api.ClientError{Resource: resource, Identifier: id, Operation: http.MethodPost, Wrapped: err}
 	}
@@ -237,7 +153,10 @@ func (c Client) AddRecipients(ctx context.Context, id string, data []byte) error
 }
 
 // RemoveRecipients removes recipients from a specific direct share.
-func (c Client) RemoveRecipients(ctx context.Context, id string, data []byte) error {
+func (c Client) RemoveRecipients(ctx context.Context, id string, data []byte) (err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "directshares.Client.RemoveRecipients", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
 		return idValidationErr
 	}
@@ -247,7 +166,7 @@ func (c Client) RemoveRecipients(ctx context.Context, id string, data []byte) er
 		return api.RuntimeError{Resource: resource, Identifier: id, Reason: "failed to construct URL", Wrapped: err}
 	}
 
-	httpResp, err := c.restClient.POST(ctx, path, bytes.NewReader(data), rest.RequestOptions{})
+	httpResp, err := c.restClient.POST(ctx, path, bytes.NewReader(data), rest.RequestOptions{Endpoint: recipientsEndpoint + "/remove"})
 	if err != nil {
 		return api.ClientError{Resource: resource, Identifier: id, Operation: http.MethodPost, Wrapped: err}
 	}
@@ -260,7 +179,10 @@ func (c Client) RemoveRecipients(ctx context.Context, id string, data []byte) er
 }
 
 // Create creates a document direct share.
-func (c Client) Create(ctx context.Context, data []byte) (api.Response, error) {
+func (c Client) Create(ctx context.Context, data []byte) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "directshares.Client.Create")
+	defer func() { endOperation(err) }()
+
 	httpResp, err := c.restClient.POST(ctx, directSharesResourcePath, bytes.NewReader(data), rest.RequestOptions{})
 	if err != nil {
 		return api.Response{}, api.ClientError{Resource: resource, Operation: http.MethodPost, Wrapped: err}
@@ -275,6 +197,9 @@ func (c Client) Create(ctx context.Context, data []byte) (api.Response, error) {
 
 // Delete removes a given document direct share by ID.
 func (c Client) Delete(ctx context.Context, id string) (err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "directshares.Client.Delete", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
 		return idValidationErr
 	}
@@ -284,7 +209,7 @@ func (c Client) Delete(ctx context.Context, id string) (err error) {
 		return api.RuntimeError{Resource: resource, Identifier: id, Reason: "failed to construct URL", Wrapped: err}
 	}
 
-	httpResp, err := c.restClient.DELETE(ctx, path, rest.RequestOptions{})
+	httpResp, err := c.restClient.DELETE(ctx, path, rest.RequestOptions{Endpoint: directShareEndpoint})
 	if err != nil {
 		return api.ClientError{Resource: resource, Identifier: id, Operation: http.MethodDelete, Wrapped: err}
 	}
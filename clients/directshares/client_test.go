@@ -217,6 +217,7 @@ func TestGet(t *testing.T) {
 
 		assert.Empty(t, actual)
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "direct-shares", Field: "id", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 
 	})
 
@@ -247,6 +248,7 @@ func TestGet(t *testing.T) {
 		var apiErr api.APIError
 		assert.ErrorAs(t, err, &apiErr)
 		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.ErrorIs(t, err, api.ErrNotFound)
 		assert.Equal(t, errorResponse, string(apiErr.Body))
 	})
 
@@ -321,6 +323,7 @@ func TestGetRecipients(t *testing.T) {
 
 		assert.Empty(t, resp)
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "direct-shares", Field: "id", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if can't execute all calls successfully", func(t *testing.T) {
@@ -389,6 +392,7 @@ func TestGetRecipients(t *testing.T) {
 		var apiErr api.APIError
 		assert.ErrorAs(t, err, &apiErr)
 		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.ErrorIs(t, err, api.ErrNotFound)
 		assert.Equal(t, errorResponse, string(apiErr.Body))
 	})
 
@@ -455,6 +459,7 @@ func TestAddRecipients(t *testing.T) {
 		err := client.AddRecipients(t.Context(), "", []byte(`{}`))
 
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "direct-shares", Field: "id", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if server returns an error", func(t *testing.T) {
@@ -482,6 +487,7 @@ func TestAddRecipients(t *testing.T) {
 		var apiErr api.APIError
 		assert.ErrorAs(t, err, &apiErr)
 		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.ErrorIs(t, err, api.ErrNotFound)
 		assert.Equal(t, errorResponse, string(apiErr.Body))
 	})
 
@@ -527,6 +533,7 @@ func TestRemoveRecipients(t *testing.T) {
 		err := client.RemoveRecipients(t.Context(), "", []byte(`{}`))
 
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "direct-shares", Field: "id", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if server returns an error", func(t *testing.T) {
@@ -671,6 +678,7 @@ func TestDelete(t *testing.T) {
 		err := client.Delete(t.Context(), "")
 
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "direct-shares", Field: "id", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if direct share with ID doesn't exist on server", func(t *testing.T) {
@@ -698,6 +706,7 @@ func TestDelete(t *testing.T) {
 		var apiErr api.APIError
 		assert.ErrorAs(t, err, &apiErr)
 		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.ErrorIs(t, err, api.ErrNotFound)
 		assert.Equal(t, get404Response, string(apiErr.Body))
 	})
 
@@ -711,3 +720,77 @@ func TestDelete(t *testing.T) {
 		assert.ErrorAs(t, err, &api.ClientError{})
 	})
 }
+
+func TestListObjects(t *testing.T) {
+	t.Run("fetches pages lazily", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					require.Equal(t, "/platform/document/v1/direct-shares", req.URL.Path)
+					return testutils.Response{ResponseCode: http.StatusOK, ResponseBody: `{"nextPageKey": "next", "direct-shares": [{"id": "share-1"}, {"id": "share-2"}]}`}
+				},
+			},
+		}
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		client := directshares.NewClient(rest.NewClient(server.URL(), server.Client()))
+
+		var ids []string
+		for o, err := range api.DecodeObjects[struct{ ID string }](client.ListObjects(t.Context())) {
+			require.NoError(t, err)
+			ids = append(ids, o.ID)
+			if len(ids) == 2 {
+				break
+			}
+		}
+		assert.Equal(t, []string{"share-1", "share-2"}, ids, "the second page must not be fetched")
+	})
+
+	t.Run("pages expose the response of each page", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					return testutils.Response{ResponseCode: http.StatusOK, ResponseBody: `{"nextPageKey": "next", "direct-shares": [{"id": "share-1"}]}`}
+				},
+			},
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					require.Equal(t, "next", req.URL.Query().Get("page-key"))
+					return testutils.Response{ResponseCode: http.StatusOK, ResponseBody: `{"direct-shares": [{"id": "share-2"}]}`}
+				},
+			},
+		}
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		client := directshares.NewClient(rest.NewClient(server.URL(), server.Client()))
+
+		var objects []int
+		for page, err := range client.ListPages(t.Context()) {
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, page.StatusCode)
+			objects = append(objects, len(page.Objects))
+		}
+		assert.Equal(t, []int{1, 1}, objects)
+	})
+
+	t.Run("errors are returned by the iterator", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					return testutils.Response{ResponseCode: http.StatusForbidden, ResponseBody: `{}`}
+				},
+			},
+		}
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		client := directshares.NewClient(rest.NewClient(server.URL(), server.Client()))
+
+		for _, err := range client.ListObjects(t.Context()) {
+			assert.ErrorIs(t, err, api.ErrForbidden)
+			assert.ErrorAs(t, err, &api.ClientError{})
+		}
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"log/slog"
 	"mime"
 	"mime/multipart"
@@ -38,6 +39,9 @@ import (
 const (
 	documentResourcePath    = "/platform/document/v1/documents"
 	trashResourcePath       = "/platform/document/v1/trash/documents"
+	documentEndpoint        = documentResourcePath + "/{id}"
+	contentEndpoint         = documentEndpoint + "/content"
+	trashEndpoint           = trashResourcePath + "/{id}"
 	optimisticLockingHeader = "optimistic-locking-version"
 
 	errMsg         = "failed to %s document: %w"
@@ -53,7 +57,7 @@ const (
 )
 
 var (
-	ErrIDEmpty    = fmt.Errorf("id must be non-empty")
+	ErrIDEmpty    = api.NewValidationSentinel("id must be non-empty")
 	ErrNoMetadata = fmt.Errorf("metadata field not found in response")
 	ErrNoContent  = fmt.Errorf("content field not found in response")
 )
@@ -91,7 +95,10 @@ type ListResponse struct {
 	Responses []Response
 }
 
-func (c Client) Get(ctx context.Context, id string) (Response, error) {
+func (c Client) Get(ctx context.Context, id string) (_ Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "documents.Client.Get", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
 		return Response{}, fmt.Errorf(errMsg, getOperation, ErrIDEmpty)
 	}
@@ -99,6 +106,32 @@ func (c Client) Get(ctx context.Context, id string) (Response, error) {
 	return c.get(ctx, id, true)
 }
 
+// GetContentStream returns the content of the document with the given ID without reading it into memory, e.g. to
+// download large documents using a rest.Client in streaming mode. The caller must close the Body of the response.
+func (c Client) GetContentStream(ctx context.Context, id string) (_ api.StreamResponse, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "documents.Client.GetContentStream", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
+	if id == "" {
+		return api.StreamResponse{}, fmt.Errorf(errMsg, getOperation, ErrIDEmpty)
+	}
+
+	path, err := url.JoinPath(documentResourcePath, id, "content")
+	if err != nil {
+		return api.StreamResponse{}, fmt.Errorf(errMsgWithID, getOperation, id, err)
+	}
+
+	httpResp, err := c.restClient.GET(ctx, path, rest.RequestOptions{Endpoint: contentEndpoint})
+	if err != nil {
+		return api.StreamResponse{}, fmt.Errorf(errMsgWithID, getOperation, id, err)
+	}
+	resp, err := api.NewStreamResponseFromHTTPResponse(httpResp)
+	if err != nil {
+		return api.StreamResponse{}, fmt.Errorf(errMsgWithID, getOperation, id, err)
+	}
+	return resp, nil
+}
+
 func readMetadata(form *multipart.Form) (Metadata, error) {
 	if len(form.Value["metadata"]) == 0 {
 		return Metadata{}, ErrNoMetadata
@@ -125,59 +158,70 @@ func readFileContent(form *multipart.Form) ([]byte, error) {
 	return fileContent.Bytes(), nil
 }
 
+// List returns the metadata of all documents matching the given filter, fetching all pages before returning. See
+// ListPages to fetch pages lazily.
 func (c Client) List(ctx context.Context, filter string) (ListResponse, error) {
-	type listResponse struct {
-		TotalCount  int        `json:"totalCount"`
-		Documents   []Metadata `json:"documents"`
-		NextPageKey *string    `json:"nextPageKey"`
-	}
-
 	var retVal ListResponse
-	var result listResponse
-	var initialPage = ""
-	result.NextPageKey = &initialPage
-
-	for result.NextPageKey != nil {
-
-		queryParams := url.Values{"filter": {filter}, "add-field": {"originExtensionId"}}
-		if *result.NextPageKey != "" {
-			queryParams["page-key"] = []string{*result.NextPageKey}
-		}
-
-		ro := rest.RequestOptions{QueryParams: queryParams}
-
-		resp, err := c.restClient.GET(ctx, documentResourcePath, ro)
-		if err != nil {
-			return ListResponse{}, fmt.Errorf(errMsg, listOperation, err)
-		}
-		res, err := api.NewResponseFromHTTPResponse(resp)
-
-		if err != nil {
-			return ListResponse{}, fmt.Errorf(errMsg, listOperation, err)
-		}
-
-		err = json.Unmarshal(res.Data, &result)
+	for page, err := range c.pages(ctx, "documents.Client.List", filter) {
 		if err != nil {
 			return ListResponse{}, err
 		}
 
-		for _, metadata := range result.Documents {
+		for _, o := range page.Objects {
+			var metadata Metadata
+			if err := json.Unmarshal(o, &metadata); err != nil {
+				return ListResponse{}, err
+			}
 			retVal.Responses = append(retVal.Responses, Response{
 				Response: api.Response{
-					Request:    rest.RequestInfo{Method: resp.Request.Method, URL: resp.Request.URL.String()},
-					StatusCode: resp.StatusCode,
+					Request:    page.Request,
+					StatusCode: page.StatusCode,
 				},
 				Metadata: metadata,
 			})
 		}
 
-		retVal.StatusCode = resp.StatusCode
+		retVal.StatusCode = page.StatusCode
 	}
 
 	return retVal, nil
 }
 
-func (c Client) Create(ctx context.Context, name string, isPrivate bool, id string, data []byte, documentType DocumentType) (api.Response, error) {
+// ListPages returns an iterator over the pages of the metadata of all documents matching the given filter. Pages are
+// fetched lazily while iterating, and no further pages are fetched once the iteration is stopped.
+func (c Client) ListPages(ctx context.Context, filter string) iter.Seq2[api.ListResponse, error] {
+	return c.pages(ctx, "documents.Client.ListPages", filter)
+}
+
+// ListObjects returns an iterator over the metadata of all documents matching the given filter, fetching pages lazily
+// like ListPages. Use ListMetadata to iterate over typed objects.
+func (c Client) ListObjects(ctx context.Context, filter string) iter.Seq2[[]byte, error] {
+	return api.Objects(c.pages(ctx, "documents.Client.ListObjects", filter))
+}
+
+// ListMetadata returns an iterator over the Metadata of all documents matching the given filter, fetching pages lazily
+// like ListPages.
+func (c Client) ListMetadata(ctx context.Context, filter string) iter.Seq2[Metadata, error] {
+	return api.DecodeObjects[Metadata](api.Objects(c.pages(ctx, "documents.Client.ListMetadata", filter)))
+}
+
+func (c Client) pages(ctx context.Context, operation string, filter string) iter.Seq2[api.ListResponse, error] {
+	paginator := api.Paginator{
+		Client:       c.restClient,
+		Strategy:     api.PageKeyStrategy(),
+		Path:         documentResourcePath,
+		Options:      rest.RequestOptions{QueryParams: url.Values{"filter": {filter}, "add-field": {"originExtensionId"}}},
+		ObjectsField: "documents",
+		Resource:     "documents",
+		WrapError:    func(err error) error { return fmt.Errorf(errMsg, listOperation, err) },
+	}
+	return paginator.Pages(ctx, operation)
+}
+
+func (c Client) Create(ctx context.Context, name string, isPrivate bool, id string, data []byte, documentType DocumentType) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "documents.Client.Create", slog.String("name", name))
+	defer func() { endOperation(err) }()
+
 	d := Document{
 		Kind:    documentType,
 		Name:    name,
@@ -221,7 +265,10 @@ func (c Client) Create(ctx context.Context, name string, isPrivate bool, id stri
 	return r, nil
 }
 
-func (c Client) Update(ctx context.Context, id string, name string, isPrivate bool, data []byte, documentType DocumentType) (api.Response, error) {
+func (c Client) Update(ctx context.Context, id string, name string, isPrivate bool, data []byte, documentType DocumentType) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "documents.Client.Update", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
 		return api.Response{}, fmt.Errorf(errMsg, updateOperation, ErrIDEmpty)
 	}
@@ -241,7 +288,10 @@ func (c Client) Update(ctx context.Context, id string, name string, isPrivate bo
 	return c.patch(ctx, id, resp.Version, d)
 }
 
-func (c Client) Delete(ctx context.Context, id string) (api.Response, error) {
+func (c Client) Delete(ctx context.Context, id string) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "documents.Client.Delete", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
 		return api.Response{}, fmt.Errorf(errMsg, deleteOperation, ErrIDEmpty)
 	}
@@ -281,6 +331,7 @@ func (c Client) patch(ctx context.Context, id string, version int, d Document) (
 	}
 
 	httpResp, err := c.restClient.PATCH(ctx, path, body, rest.RequestOptions{
+		Endpoint:    documentEndpoint,
 		ContentType: writer.FormDataContentType(),
 		QueryParams: url.Values{optimisticLockingHeader: []string{strconv.Itoa(version)}},
 	})
@@ -307,7 +358,7 @@ func (c Client) get(ctx context.Context, id string, readContent bool) (Response,
 		return Response{}, fmt.Errorf(errMsg, getOperation, err)
 	}
 
-	httpResp, err := c.restClient.GET(ctx, path, rest.RequestOptions{})
+	httpResp, err := c.restClient.GET(ctx, path, rest.RequestOptions{Endpoint: documentEndpoint})
 	if err != nil {
 		return Response{}, fmt.Errorf(errMsgWithID, getOperation, id, err)
 	}
@@ -331,7 +382,7 @@ func (c Client) get(ctx context.Context, id string, readContent bool) (Response,
 	defer func() {
 		err := form.RemoveAll()
 		if err != nil {
-			slog.WarnContext(ctx, "Failed to remove multipart form temporary files", slog.String("error", err.Error()))
+			c.restClient.Logger(ctx).WarnContext(ctx, "Failed to remove multipart form temporary files", slog.String("error", err.Error()))
 		}
 	}()
 
@@ -372,6 +423,7 @@ func (c Client) delete(ctx context.Context, id string, version int) (api.Respons
 	}
 
 	r, err := c.restClient.DELETE(ctx, path, rest.RequestOptions{
+		Endpoint:    documentEndpoint,
 		QueryParams: map[string][]string{optimisticLockingHeader: {strconv.Itoa(version)}},
 	})
 	if err != nil {
@@ -391,7 +443,7 @@ func (c Client) trash(ctx context.Context, id string) (api.Response, error) {
 		return api.Response{}, fmt.Errorf(errMsgWithID, trashOperation, id, err)
 	}
 
-	resp, err := c.restClient.DELETE(ctx, path, rest.RequestOptions{})
+	resp, err := c.restClient.DELETE(ctx, path, rest.RequestOptions{Endpoint: trashEndpoint})
 	if err != nil {
 		return api.Response{}, fmt.Errorf(errMsgWithID, trashOperation, id, err)
 	}
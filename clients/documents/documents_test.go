@@ -17,8 +17,10 @@ package documents_test
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -255,6 +257,58 @@ This is the document content`
 	})
 }
 
+func TestDocumentClient_GetContentStream(t *testing.T) {
+	t.Run("streams the content", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					require.Equal(t, "/platform/document/v1/documents/b17ec54b-07ac-4c73-9c4d-232e1b2e2420/content", req.URL.Path)
+					return testutils.Response{ResponseCode: http.StatusOK, ResponseBody: "This is the document content"}
+				},
+			},
+		}
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		client := documents.NewClient(rest.NewClient(server.URL(), server.Client()))
+
+		resp, err := client.GetContentStream(t.Context(), "b17ec54b-07ac-4c73-9c4d-232e1b2e2420")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "This is the document content", string(data))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NotZero(t, resp.Request)
+	})
+
+	t.Run("no ID given", func(t *testing.T) {
+		client := documents.NewClient(rest.NewClient(nil, nil))
+
+		_, err := client.GetContentStream(t.Context(), "")
+		assert.ErrorIs(t, err, documents.ErrIDEmpty)
+	})
+
+	t.Run("API Call returned with != 2xx", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					return testutils.Response{ResponseCode: http.StatusNotFound}
+				},
+			},
+		}
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		client := documents.NewClient(rest.NewClient(server.URL(), server.Client()))
+
+		resp, err := client.GetContentStream(t.Context(), "b17ec54b-07ac-4c73-9c4d-232e1b2e2420")
+		assert.Zero(t, resp)
+		assert.ErrorIs(t, err, api.ErrNotFound)
+	})
+}
+
 // getTemporaryMultipartFileCount counts the number of temporary files with the prefix "multipart-".
 // These are typically created by multipart reader in the system's temp directory.
 // This function is used to ensure that such files are properly cleaned up after processing multipart responses.
@@ -325,6 +379,56 @@ func TestDocumentClient_Create(t *testing.T) {
 		assert.JSONEq(t, expected, string(res.Data))
 	})
 
+	t.Run("dry run", func(t *testing.T) {
+		server := testutils.NewHTTPTestServer(t, []testutils.ResponseDef{})
+		defer server.Close()
+
+		restClient := rest.NewClient(server.URL(), server.Client(), rest.WithDryRun(rest.DryRunOptions{}))
+		client := documents.NewClient(restClient)
+
+		_, err := client.Create(t.Context(), "name", false, "extID", []byte("this is the content"), documents.Notebook)
+
+		require.NoError(t, err)
+		plan := restClient.DryRunPlan().Requests()
+		require.Len(t, plan, 2)
+		assert.Equal(t, http.MethodPost, plan[0].Method)
+		assert.Equal(t, http.MethodPatch, plan[1].Method)
+		assert.Zero(t, server.Calls())
+	})
+
+	t.Run("recorded interactions are replayed", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				POST: func(t *testing.T, req *http.Request) testutils.Response {
+					return testutils.Response{ResponseCode: http.StatusCreated, ResponseBody: respCreate}
+				},
+			},
+			{
+				PATCH: func(t *testing.T, req *http.Request) testutils.Response {
+					return testutils.Response{ResponseCode: http.StatusOK, ResponseBody: respPatch}
+				},
+			},
+		}
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+		cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+		recorder, err := rest.NewRecorder(rest.RecorderOptions{Mode: rest.RecordMode, Path: cassette})
+		require.NoError(t, err)
+		client := documents.NewClient(rest.NewClient(server.URL(), server.Client(), rest.WithRecorder(recorder)))
+		_, err = client.Create(t.Context(), "name", false, "extID", []byte(`{"a": 1}`), documents.Notebook)
+		require.NoError(t, err)
+		require.NoError(t, recorder.Save())
+
+		replayer, err := rest.NewRecorder(rest.RecorderOptions{Mode: rest.ReplayMode, Path: cassette})
+		require.NoError(t, err)
+		client = documents.NewClient(rest.NewClient(server.URL(), server.FaultyClient(), rest.WithRecorder(replayer)))
+		res, err := client.Create(t.Context(), "name", false, "extID", []byte(`{"a": 1}`), documents.Notebook)
+		require.NoError(t, err)
+		assert.JSONEq(t, expected, string(res.Data))
+		assert.Equal(t, 2, server.Calls(), "expected replayed requests not to be sent")
+	})
+
 	t.Run("create call returns invalid response body", func(t *testing.T) {
 
 		responses := []testutils.ResponseDef{
@@ -597,7 +701,8 @@ This is the document content
 
 		resp, err := client.Update(t.Context(), "", "my-dashboard", true, []byte(documentContent), documents.Dashboard)
 		assert.Zero(t, resp)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, documents.ErrIDEmpty)
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("Update - Document not found", func(t *testing.T) {
@@ -618,7 +723,8 @@ This is the document content
 
 		resp, err := client.Update(t.Context(), "038ab74f-0a3a-4bf8-9068-85e2d633a1e6", "my-dashboard", true, []byte(documentContent), documents.Dashboard)
 		assert.Zero(t, resp)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, api.ErrNotFound)
+		assert.ErrorContains(t, err, "038ab74f-0a3a-4bf8-9068-85e2d633a1e6")
 	})
 
 	t.Run("Update - Fails to fetch existing document", func(t *testing.T) {
@@ -910,6 +1016,69 @@ func TestDocumentClient_List(t *testing.T) {
 
 	})
 
+	t.Run("ListMetadata - fetches pages lazily", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					return testutils.Response{
+						ResponseCode: http.StatusOK,
+						ResponseBody: listPayloadPage1,
+					}
+				},
+			},
+		}
+
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		client := documents.NewClient(rest.NewClient(server.URL(), server.Client()))
+
+		var ids []string
+		for metadata, err := range client.ListMetadata(t.Context(), "type == 'dashboard'") {
+			require.NoError(t, err)
+			ids = append(ids, metadata.ID)
+			break
+		}
+		assert.Equal(t, []string{"id1"}, ids, "the second page must not be fetched")
+	})
+
+	t.Run("ListPages - OK", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					return testutils.Response{
+						ResponseCode: http.StatusOK,
+						ResponseBody: listPayloadPage1,
+					}
+				},
+			},
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					return testutils.Response{
+						ResponseCode: http.StatusOK,
+						ResponseBody: listPayloadPage2,
+					}
+				},
+				ValidateRequest: func(t *testing.T, request *http.Request) {
+					assert.Equal(t, "next", request.URL.Query().Get("page-key"))
+				},
+			},
+		}
+
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		client := documents.NewClient(rest.NewClient(server.URL(), server.Client()))
+
+		var objects [][]byte
+		for page, err := range client.ListPages(t.Context(), "") {
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, page.StatusCode)
+			objects = append(objects, page.Objects...)
+		}
+		assert.Len(t, objects, 2)
+	})
+
 	t.Run("List - Loading Page Fails", func(t *testing.T) {
 		responses := []testutils.ResponseDef{
 			{
@@ -17,10 +17,10 @@ package extensions
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"iter"
+	"log/slog"
 	"net/http"
 	"net/url"
-	"strconv"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
@@ -30,6 +30,11 @@ const (
 	extensionsResourcePath           = "/platform/extensions/v2/extensions"
 	monitoringResourcePath           = "monitoring-configurations"
 	environmentConfigurationPath     = "environment-configuration"
+	extensionEndpoint                = extensionsResourcePath + "/{extensionName}"
+	extensionVersionEndpoint         = extensionEndpoint + "/{extensionVersion}"
+	monitoringConfigurationsEndpoint = extensionEndpoint + "/" + monitoringResourcePath
+	monitoringConfigurationEndpoint  = monitoringConfigurationsEndpoint + "/{configurationId}"
+	environmentConfigurationEndpoint = extensionEndpoint + "/" + environmentConfigurationPath
 	extensionsResource               = "extensions"
 	monitoringConfigurationsResource = "monitoring-configurations"
 	environmentConfigurationResource = "environment-configuration"
@@ -40,8 +45,9 @@ const (
 )
 
 var (
-	extensionNameValidationErr   = api.ValidationError{Resource: extensionsResource, Field: "extension-name", Reason: "is empty"}
-	configurationIDValidationErr = api.ValidationError{Resource: monitoringConfigurationsResource, Field: "configuration-id", Reason: "is empty"}
+	extensionNameValidationErr    = api.ValidationError{Resource: extensionsResource, Field: "extension-name", Reason: "is empty"}
+	extensionVersionValidationErr = api.ValidationError{Resource: extensionsResource, Field: "extension-version", Reason: "is empty"}
+	configurationIDValidationErr  = api.ValidationError{Resource: monitoringConfigurationsResource, Field: "configuration-id", Reason: "is empty"}
 )
 
 // Client is used to interact with the Extensions API.
@@ -54,43 +60,26 @@ func NewClient(client *rest.Client) *Client {
 	return &Client{restClient: client}
 }
 
-// ListExtensions returns all extensions.
+// ListExtensions returns all extensions, fetching all pages before returning. See ListExtensionPages to fetch pages
+// lazily.
 func (c Client) ListExtensions(ctx context.Context) (api.PagedListResponse, error) {
-	var pagedListResponse api.PagedListResponse
-
-	nextPageKey := ""
-	for {
-		var listResponse api.ListResponse
-		var err error
-
-		nextPageKey, listResponse, err = c.listExtensionsPage(ctx, nextPageKey)
-		if err != nil {
-			return nil, err
-		}
-
-		pagedListResponse = append(pagedListResponse, listResponse)
-		if nextPageKey == "" {
-			break
-		}
-	}
-
-	return pagedListResponse, nil
+	return api.CollectPages(c.extensionPages(ctx, "extensions.Client.ListExtensions"))
 }
 
-func (c Client) listExtensionsPage(ctx context.Context, pageKey string) (string, api.ListResponse, error) {
-	var ro rest.RequestOptions
-	if pageKey != "" {
-		ro.QueryParams = url.Values{"next-page-key": {pageKey}}
-	} else {
-		ro.QueryParams = url.Values{"page-size": {strconv.Itoa(extensionsPageSize)}}
-	}
+// ListExtensionPages returns an iterator over the pages of all extensions. Pages are fetched lazily while iterating,
+// and no further pages are fetched once the iteration is stopped.
+func (c Client) ListExtensionPages(ctx context.Context) iter.Seq2[api.ListResponse, error] {
+	return c.extensionPages(ctx, "extensions.Client.ListExtensionPages")
+}
 
-	resp, err := c.restClient.GET(ctx, extensionsResourcePath, ro)
-	if err != nil {
-		return "", api.ListResponse{}, api.ClientError{Resource: extensionsResource, Operation: http.MethodGet, Wrapped: err}
-	}
+// ListExtensionObjects returns an iterator over all extensions, fetching pages lazily like ListExtensionPages. Use
+// api.DecodeObjects to iterate over typed objects.
+func (c Client) ListExtensionObjects(ctx context.Context) iter.Seq2[[]byte, error] {
+	return api.Objects(c.extensionPages(ctx, "extensions.Client.ListExtensionObjects"))
+}
 
-	return processListResponse(resp, extensionsResource)
+func (c Client) extensionPages(ctx context.Context, operation string) iter.Seq2[api.ListResponse, error] {
+	return c.paginator(extensionsResourcePath, "", extensionsResource, "", extensionsPageSize).Pages(ctx, operation)
 }
 
 // ListExtensionVersions returns all installed versions of a given extension.
@@ -103,7 +92,7 @@ func (c Client) ListExtensionVersions(ctx context.Context, extensionName string)
 	if err != nil {
 		return nil, api.RuntimeError{Resource: extensionsResource, Identifier: extensionName, Reason: urlCreationErrMsg, Wrapped: err}
 	}
-	return c.listAll(ctx, extensionName, path, extensionsResource, extensionVersionsPageSize)
+	return api.CollectPages(c.paginator(path, extensionEndpoint, extensionsResource, extensionName, extensionVersionsPageSize).Pages(ctx, "extensions.Client.ListExtensionVersions", slog.String("extensionName", extensionName)))
 }
 
 // ListMonitoringConfigurations returns all monitoring configurations for a given extension.
@@ -117,81 +106,61 @@ func (c Client) ListMonitoringConfigurations(ctx context.Context, extensionName
 		return nil, api.RuntimeError{Resource: monitoringConfigurationsResource, Identifier: extensionName, Reason: urlCreationErrMsg, Wrapped: err}
 	}
 
-	return c.listAll(ctx, extensionName, path, monitoringConfigurationsResource, monitoringConfigurationsPageSize)
+	return api.CollectPages(c.paginator(path, monitoringConfigurationsEndpoint, monitoringConfigurationsResource, extensionName, monitoringConfigurationsPageSize).Pages(ctx, "extensions.Client.ListMonitoringConfigurations", slog.String("extensionName", extensionName)))
 }
 
-// listAll is a helper method to list paged resources.
-// It takes care of paging through results until all pages have been retrieved and returns a combined PagedListResponse.
-func (c Client) listAll(ctx context.Context, extensionName string, path string, resourceName string, pageSize int) (api.PagedListResponse, error) {
-	var pagedListResponse api.PagedListResponse
-	var nextPageKey string
-
-	for {
-		var listResponse api.ListResponse
-		var err error
-
-		var ro rest.RequestOptions
-		if nextPageKey != "" {
-			ro.QueryParams = url.Values{"next-page-key": {nextPageKey}}
-		} else {
-			ro.QueryParams = url.Values{"page-size": {strconv.Itoa(pageSize)}}
-		}
-
-		httpResp, err := c.restClient.GET(ctx, path, ro)
-		if err != nil {
-			return nil, api.ClientError{Resource: resourceName, Identifier: extensionName, Operation: http.MethodGet, Wrapped: err}
-		}
-
-		nextPageKey, listResponse, err = processListResponse(httpResp, resourceName)
-
-		if err != nil {
-			return nil, err
-		}
-
-		pagedListResponse = append(pagedListResponse, listResponse)
-		if nextPageKey == "" {
-			break
-		}
+// paginator returns the api.Paginator of the list at the given path, identified by the given extension name, if any.
+// All list endpoints return the objects in the "items" field.
+func (c Client) paginator(path string, endpoint string, resourceName string, extensionName string, pageSize int) api.Paginator {
+	return api.Paginator{
+		Client:            c.restClient,
+		Strategy:          api.NextPageKeyStrategy(),
+		Path:              path,
+		Options:           rest.RequestOptions{Endpoint: endpoint},
+		ObjectsField:      "items",
+		Resource:          resourceName,
+		PaginationOptions: api.PaginationOptions{PageSize: pageSize},
+		WrapError: func(err error) error {
+			return api.ClientError{Resource: resourceName, Identifier: extensionName, Operation: http.MethodGet, Wrapped: err}
+		},
 	}
-	return pagedListResponse, nil
 }
 
-// processListResponse is shared by both list endpoints. It unmarshals the "nextPageKey" and "items" fields.
-func processListResponse(httpResponse *http.Response, resource string) (string, api.ListResponse, error) {
-	resp, err := api.NewResponseFromHTTPResponse(httpResponse)
-	if err != nil {
-		return "", api.ListResponse{}, api.ClientError{Resource: resource, Operation: http.MethodGet, Wrapped: err}
-	}
+// DownloadExtension returns the package of the given version of an extension without reading it into memory, e.g. to
+// download large packages using a rest.Client in streaming mode. The caller must close the Body of the response.
+func (c Client) DownloadExtension(ctx context.Context, extensionName string, extensionVersion string) (_ api.StreamResponse, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "extensions.Client.DownloadExtension", slog.String("extensionName", extensionName), slog.String("extensionVersion", extensionVersion))
+	defer func() { endOperation(err) }()
 
-	var listResp struct {
-		NextPage string            `json:"nextPageKey"`
-		Items    []json.RawMessage `json:"items"`
+	if extensionName == "" {
+		return api.StreamResponse{}, extensionNameValidationErr
+	}
+	if extensionVersion == "" {
+		return api.StreamResponse{}, extensionVersionValidationErr
 	}
 
-	if err := json.Unmarshal(resp.Data, &listResp); err != nil {
-		return "", api.ListResponse{}, api.RuntimeError{Resource: resource, Reason: "unmarshalling failed", Wrapped: err}
+	path, err := url.JoinPath(extensionsResourcePath, extensionName, extensionVersion)
+	if err != nil {
+		return api.StreamResponse{}, api.RuntimeError{Resource: extensionsResource, Identifier: extensionName, Reason: urlCreationErrMsg, Wrapped: err}
 	}
 
-	var objects [][]byte
-	for _, it := range listResp.Items {
-		objects = append(objects, it)
+	httpResp, err := c.restClient.GET(ctx, path, rest.RequestOptions{Endpoint: extensionVersionEndpoint, Accept: "application/octet-stream"})
+	if err != nil {
+		return api.StreamResponse{}, api.ClientError{Resource: extensionsResource, Identifier: extensionName, Operation: http.MethodGet, Wrapped: err}
 	}
 
-	return listResp.NextPage,
-		api.ListResponse{
-			Response: api.Response{
-				StatusCode: httpResponse.StatusCode,
-				Header:     httpResponse.Header,
-				Data:       nil,
-				Request:    api.NewRequestInfoFromRequest(httpResponse.Request),
-			},
-			Objects: objects,
-		},
-		nil
+	resp, err := api.NewStreamResponseFromHTTPResponse(httpResp)
+	if err != nil {
+		return api.StreamResponse{}, api.ClientError{Resource: extensionsResource, Identifier: extensionName, Operation: http.MethodGet, Wrapped: err}
+	}
+	return resp, nil
 }
 
 // GetEnvironmentConfiguration returns the environment configuration for a given extension.
-func (c Client) GetEnvironmentConfiguration(ctx context.Context, extensionName string) (api.Response, error) {
+func (c Client) GetEnvironmentConfiguration(ctx context.Context, extensionName string) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "extensions.Client.GetEnvironmentConfiguration", slog.String("extensionName", extensionName))
+	defer func() { endOperation(err) }()
+
 	if extensionName == "" {
 		return api.Response{}, extensionNameValidationErr
 	}
@@ -201,7 +170,7 @@ func (c Client) GetEnvironmentConfiguration(ctx context.Context, extensionName s
 		return api.Response{}, api.RuntimeError{Resource: environmentConfigurationResource, Identifier: extensionName, Reason: urlCreationErrMsg, Wrapped: err}
 	}
 
-	httpResp, err := c.restClient.GET(ctx, path, rest.RequestOptions{})
+	httpResp, err := c.restClient.GET(ctx, path, rest.RequestOptions{Endpoint: environmentConfigurationEndpoint})
 	if err != nil {
 		return api.Response{}, api.ClientError{Resource: environmentConfigurationResource, Identifier: extensionName, Operation: http.MethodGet, Wrapped: err}
 	}
@@ -214,7 +183,10 @@ func (c Client) GetEnvironmentConfiguration(ctx context.Context, extensionName s
 }
 
 // GetMonitoringConfiguration returns a specific monitoring configuration by extension name and configuration ID.
-func (c Client) GetMonitoringConfiguration(ctx context.Context, extensionName string, configurationID string) (api.Response, error) {
+func (c Client) GetMonitoringConfiguration(ctx context.Context, extensionName string, configurationID string) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "extensions.Client.GetMonitoringConfiguration", slog.String("extensionName", extensionName), slog.String("id", configurationID))
+	defer func() { endOperation(err) }()
+
 	if extensionName == "" {
 		return api.Response{}, extensionNameValidationErr
 	}
@@ -227,7 +199,7 @@ func (c Client) GetMonitoringConfiguration(ctx context.Context, extensionName st
 		return api.Response{}, api.RuntimeError{Resource: monitoringConfigurationsResource, Identifier: configurationID, Reason: urlCreationErrMsg, Wrapped: err}
 	}
 
-	httpResp, err := c.restClient.GET(ctx, path, rest.RequestOptions{})
+	httpResp, err := c.restClient.GET(ctx, path, rest.RequestOptions{Endpoint: monitoringConfigurationEndpoint})
 	if err != nil {
 		return api.Response{}, api.ClientError{Resource: monitoringConfigurationsResource, Identifier: configurationID, Operation: http.MethodGet, Wrapped: err}
 	}
@@ -240,7 +212,10 @@ func (c Client) GetMonitoringConfiguration(ctx context.Context, extensionName st
 }
 
 // CreateMonitoringConfiguration creates a new monitoring configuration for a given extension.
-func (c Client) CreateMonitoringConfiguration(ctx context.Context, extensionName string, data []byte) (api.Response, error) {
+func (c Client) CreateMonitoringConfiguration(ctx context.Context, extensionName string, data []byte) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "extensions.Client.CreateMonitoringConfiguration", slog.String("extensionName", extensionName))
+	defer func() { endOperation(err) }()
+
 	if extensionName == "" {
 		return api.Response{}, extensionNameValidationErr
 	}
@@ -250,7 +225,7 @@ func (c Client) CreateMonitoringConfiguration(ctx context.Context, extensionName
 		return api.Response{}, api.RuntimeError{Resource: monitoringConfigurationsResource, Identifier: extensionName, Reason: urlCreationErrMsg, Wrapped: err}
 	}
 
-	httpResp, err := c.restClient.POST(ctx, path, bytes.NewReader(data), rest.RequestOptions{})
+	httpResp, err := c.restClient.POST(ctx, path, bytes.NewReader(data), rest.RequestOptions{Endpoint: monitoringConfigurationsEndpoint})
 	if err != nil {
 		return api.Response{}, api.ClientError{Resource: monitoringConfigurationsResource, Identifier: extensionName, Operation: http.MethodPost, Wrapped: err}
 	}
@@ -263,7 +238,10 @@ func (c Client) CreateMonitoringConfiguration(ctx context.Context, extensionName
 }
 
 // UpdateMonitoringConfiguration updates an existing monitoring configuration.
-func (c Client) UpdateMonitoringConfiguration(ctx context.Context, extensionName string, configurationID string, data []byte) (api.Response, error) {
+func (c Client) UpdateMonitoringConfiguration(ctx context.Context, extensionName string, configurationID string, data []byte) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "extensions.Client.UpdateMonitoringConfiguration", slog.String("extensionName", extensionName), slog.String("id", configurationID))
+	defer func() { endOperation(err) }()
+
 	if extensionName == "" {
 		return api.Response{}, extensionNameValidationErr
 	}
@@ -276,7 +254,7 @@ func (c Client) UpdateMonitoringConfiguration(ctx context.Context, extensionName
 		return api.Response{}, api.RuntimeError{Resource: monitoringConfigurationsResource, Identifier: configurationID, Reason: urlCreationErrMsg, Wrapped: err}
 	}
 
-	httpResp, err := c.restClient.PUT(ctx, path, bytes.NewReader(data), rest.RequestOptions{})
+	httpResp, err := c.restClient.PUT(ctx, path, bytes.NewReader(data), rest.RequestOptions{Endpoint: monitoringConfigurationEndpoint})
 	if err != nil {
 		return api.Response{}, api.ClientError{Resource: monitoringConfigurationsResource, Identifier: configurationID, Operation: http.MethodPut, Wrapped: err}
 	}
@@ -289,7 +267,10 @@ func (c Client) UpdateMonitoringConfiguration(ctx context.Context, extensionName
 }
 
 // DeleteMonitoringConfiguration deletes a specific monitoring configuration.
-func (c Client) DeleteMonitoringConfiguration(ctx context.Context, extensionName string, configurationID string) error {
+func (c Client) DeleteMonitoringConfiguration(ctx context.Context, extensionName string, configurationID string) (err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "extensions.Client.DeleteMonitoringConfiguration", slog.String("extensionName", extensionName), slog.String("id", configurationID))
+	defer func() { endOperation(err) }()
+
 	if extensionName == "" {
 		return extensionNameValidationErr
 	}
@@ -302,7 +283,7 @@ func (c Client) DeleteMonitoringConfiguration(ctx context.Context, extensionName
 		return api.RuntimeError{Resource: monitoringConfigurationsResource, Identifier: configurationID, Reason: urlCreationErrMsg, Wrapped: err}
 	}
 
-	httpResp, err := c.restClient.DELETE(ctx, path, rest.RequestOptions{})
+	httpResp, err := c.restClient.DELETE(ctx, path, rest.RequestOptions{Endpoint: monitoringConfigurationEndpoint})
 	if err != nil {
 		return api.ClientError{Resource: monitoringConfigurationsResource, Identifier: configurationID, Operation: http.MethodDelete, Wrapped: err}
 	}
@@ -205,6 +205,7 @@ func TestListExtensionVersions(t *testing.T) {
 
 		assert.Empty(t, resp)
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "extensions", Field: "extension-name", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if can't execute all calls successfully", func(t *testing.T) {
@@ -328,6 +329,7 @@ func TestListMonitoringConfigurations(t *testing.T) {
 
 		assert.Empty(t, resp)
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "extensions", Field: "extension-name", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if can't execute all calls successfully", func(t *testing.T) {
@@ -428,6 +430,7 @@ func TestGetEnvironmentConfiguration(t *testing.T) {
 
 		assert.Empty(t, resp)
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "extensions", Field: "extension-name", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if extension doesn't exist on server", func(t *testing.T) {
@@ -456,6 +459,7 @@ func TestGetEnvironmentConfiguration(t *testing.T) {
 		var apiErr api.APIError
 		assert.ErrorAs(t, err, &apiErr)
 		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.ErrorIs(t, err, api.ErrNotFound)
 		assert.Equal(t, errorResponse, string(apiErr.Body))
 	})
 
@@ -472,6 +476,63 @@ func TestGetEnvironmentConfiguration(t *testing.T) {
 	})
 }
 
+func TestDownloadExtension(t *testing.T) {
+	t.Run("successfully streams the extension package", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					require.Equal(t, "/platform/extensions/v2/extensions/com.dynatrace.extension.foo/1.2.3", req.URL.Path)
+					require.Equal(t, "application/octet-stream", req.Header.Get("Accept"))
+					return testutils.Response{ResponseCode: http.StatusOK, ResponseBody: "zip-content", ContentType: "application/octet-stream"}
+				},
+			},
+		}
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		client := extensions.NewClient(rest.NewClient(server.URL(), server.Client()))
+
+		resp, err := client.DownloadExtension(t.Context(), "com.dynatrace.extension.foo", "1.2.3")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "zip-content", string(data))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("errors if called without extension name or version", func(t *testing.T) {
+		client := extensions.NewClient(&rest.Client{})
+
+		_, err := client.DownloadExtension(t.Context(), "", "1.2.3")
+		assert.ErrorIs(t, err, api.ValidationError{Resource: "extensions", Field: "extension-name", Reason: "is empty"})
+
+		_, err = client.DownloadExtension(t.Context(), "com.dynatrace.extension.foo", "")
+		assert.ErrorIs(t, err, api.ValidationError{Resource: "extensions", Field: "extension-version", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
+	})
+
+	t.Run("errors if extension doesn't exist on server", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, _ *http.Request) testutils.Response {
+					return testutils.Response{ResponseCode: http.StatusNotFound, ResponseBody: "{}"}
+				},
+			},
+		}
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		client := extensions.NewClient(rest.NewClient(server.URL(), server.Client()))
+
+		resp, err := client.DownloadExtension(t.Context(), "com.dynatrace.extension.unknown", "1.2.3")
+
+		assert.Empty(t, resp)
+		assert.ErrorIs(t, err, api.ErrNotFound)
+	})
+}
+
 func TestGetMonitoringConfiguration(t *testing.T) {
 	t.Run("successfully returns monitoring configuration for requested IDs", func(t *testing.T) {
 		getResponse := `{"objectId": "config-id-1", "value": {"enabled": true}}`
@@ -504,6 +565,7 @@ func TestGetMonitoringConfiguration(t *testing.T) {
 
 		assert.Empty(t, resp)
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "extensions", Field: "extension-name", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if called without configuration ID", func(t *testing.T) {
@@ -513,6 +575,7 @@ func TestGetMonitoringConfiguration(t *testing.T) {
 
 		assert.Empty(t, resp)
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "monitoring-configurations", Field: "configuration-id", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if monitoring configuration with ID doesn't exist on server", func(t *testing.T) {
@@ -541,6 +604,7 @@ func TestGetMonitoringConfiguration(t *testing.T) {
 		var apiErr api.APIError
 		assert.ErrorAs(t, err, &apiErr)
 		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.ErrorIs(t, err, api.ErrNotFound)
 		assert.Equal(t, errorResponse, string(apiErr.Body))
 	})
 
@@ -590,6 +654,7 @@ func TestCreateMonitoringConfiguration(t *testing.T) {
 
 		assert.Empty(t, resp)
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "extensions", Field: "extension-name", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if server returns an error", func(t *testing.T) {
@@ -667,6 +732,7 @@ func TestUpdateMonitoringConfiguration(t *testing.T) {
 
 		assert.Empty(t, resp)
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "extensions", Field: "extension-name", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if called without configuration ID", func(t *testing.T) {
@@ -676,6 +742,7 @@ func TestUpdateMonitoringConfiguration(t *testing.T) {
 
 		assert.Empty(t, resp)
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "monitoring-configurations", Field: "configuration-id", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if server returns an error", func(t *testing.T) {
@@ -704,6 +771,7 @@ func TestUpdateMonitoringConfiguration(t *testing.T) {
 		var apiErr api.APIError
 		assert.ErrorAs(t, err, &apiErr)
 		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.ErrorIs(t, err, api.ErrNotFound)
 		assert.Equal(t, errorResponse, string(apiErr.Body))
 	})
 
@@ -746,6 +814,7 @@ func TestDeleteMonitoringConfiguration(t *testing.T) {
 		err := client.DeleteMonitoringConfiguration(t.Context(), "", "config-id-1")
 
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "extensions", Field: "extension-name", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if called without configuration ID", func(t *testing.T) {
@@ -754,6 +823,7 @@ func TestDeleteMonitoringConfiguration(t *testing.T) {
 		err := client.DeleteMonitoringConfiguration(t.Context(), "com.dynatrace.extension.foo", "")
 
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "monitoring-configurations", Field: "configuration-id", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 	})
 
 	t.Run("errors if monitoring configuration with ID doesn't exist on server", func(t *testing.T) {
@@ -781,6 +851,7 @@ func TestDeleteMonitoringConfiguration(t *testing.T) {
 		var apiErr api.APIError
 		assert.ErrorAs(t, err, &apiErr)
 		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.ErrorIs(t, err, api.ErrNotFound)
 		assert.Equal(t, errorResponse, string(apiErr.Body))
 	})
 
@@ -795,3 +866,56 @@ func TestDeleteMonitoringConfiguration(t *testing.T) {
 		assert.ErrorAs(t, err, &api.ClientError{})
 	})
 }
+
+func TestListExtensionObjects(t *testing.T) {
+	t.Run("fetches pages lazily", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					require.Equal(t, "/platform/extensions/v2/extensions", req.URL.Path)
+					return testutils.Response{ResponseCode: http.StatusOK, ResponseBody: `{"nextPageKey": "next", "items": [{"extensionName": "com.dynatrace.extension.foo"}]}`}
+				},
+			},
+		}
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		client := extensions.NewClient(rest.NewClient(server.URL(), server.Client()))
+
+		var names []string
+		for o, err := range api.DecodeObjects[struct{ ExtensionName string }](client.ListExtensionObjects(t.Context())) {
+			require.NoError(t, err)
+			names = append(names, o.ExtensionName)
+			break
+		}
+		assert.Equal(t, []string{"com.dynatrace.extension.foo"}, names, "the second page must not be fetched")
+	})
+
+	t.Run("pages expose the response of each page", func(t *testing.T) {
+		responses := []testutils.ResponseDef{
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					return testutils.Response{ResponseCode: http.StatusOK, ResponseBody: `{"nextPageKey": "next", "items": [{"extensionName": "com.dynatrace.extension.foo"}]}`}
+				},
+			},
+			{
+				GET: func(t *testing.T, req *http.Request) testutils.Response {
+					require.Equal(t, "next", req.URL.Query().Get(nextPageKeyParam))
+					return testutils.Response{ResponseCode: http.StatusOK, ResponseBody: `{"items": [{"extensionName": "com.dynatrace.extension.bar"}]}`}
+				},
+			},
+		}
+		server := testutils.NewHTTPTestServer(t, responses)
+		defer server.Close()
+
+		client := extensions.NewClient(rest.NewClient(server.URL(), server.Client()))
+
+		var objects []int
+		for page, err := range client.ListExtensionPages(t.Context()) {
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, page.StatusCode)
+			objects = append(objects, len(page.Objects))
+		}
+		assert.Equal(t, []int{1, 1}, objects)
+	})
+}
@@ -18,12 +18,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 
 	"golang.org/x/oauth2/clientcredentials"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/auth"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/accounts"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/automation"
@@ -59,17 +61,29 @@ func Factory() factory {
 
 // factory represents a factory-like component for creating API client instances.
 type factory struct {
-	platformURL            string                    // The base URL for platform APIs
-	classicURL             string                    // The base URL for classic APIs
-	accountURL             string                    // The base URL for account APIs
-	oauthConfig            *clientcredentials.Config // Configuration for OAuth2 client credentials
-	accessToken            string                    // Access token for API
-	userAgent              string                    // The User-Agent header to be set
-	httpListener           *rest.HTTPListener        // The HTTP listener to be set
-	concurrentRequestLimit int                       // The number of allowed concurrent requests
-	rateLimiterEnabled     bool                      // Enables rate limiter for clients
-	retryOptions           *rest.RetryOptions        // The retry strategy
-	customHeaders          map[string]string         // Custom HTTP headers
+	platformURL            string                           // The base URL for platform APIs
+	classicURL             string                           // The base URL for classic APIs
+	accountURL             string                           // The base URL for account APIs
+	oauthConfig            *clientcredentials.Config        // Configuration for OAuth2 client credentials
+	accessToken            string                           // Access token for API
+	userAgent              string                           // The User-Agent header to be set
+	httpListener           *rest.HTTPListener               // The HTTP listener to be set
+	concurrentRequestLimit int                              // The number of allowed concurrent requests
+	adaptiveConcurrency    *rest.AdaptiveConcurrencyOptions // Enables adaptive concurrency limiting
+	rateLimiterEnabled     bool                             // Enables rate limiter for clients
+	retryOptions           *rest.RetryOptions               // The retry strategy
+	customHeaders          map[string]string                // Custom HTTP headers
+	tracer                 rest.Tracer                      // The tracer used to trace requests
+	metrics                rest.MetricsCollector            // The collector for request metrics
+	circuitBreaker         *rest.CircuitBreaker             // The circuit breaker shared by all clients
+	recorder               *rest.Recorder                   // The recorder for recording or replaying requests
+	cache                  rest.Cache                       // The cache for GET responses
+	requestCoalescing      bool                             // Enables coalescing of identical GET requests
+	streaming              *rest.StreamingOptions           // Enables the streaming mode for large transfers
+	idempotency            *rest.IdempotencyOptions         // Enables generating idempotency keys for POST requests
+	dryRun                 *rest.DryRunOptions              // Enables the dry-run mode
+	logger                 *slog.Logger                     // Logger of the clients
+	redactor               *redact.Redactor                 // Redactor the redactors of the clients are based on
 	platformToken          string
 }
 
@@ -129,6 +143,13 @@ func (f factory) WithConcurrentRequestLimit(limit int) factory {
 	return f
 }
 
+// WithAdaptiveConcurrency enables an adaptive concurrency limit for the underlying rest/http clients, which grows
+// while requests succeed and shrinks if the server is overloaded. It takes precedence over WithConcurrentRequestLimit.
+func (f factory) WithAdaptiveConcurrency(opts rest.AdaptiveConcurrencyOptions) factory {
+	f.adaptiveConcurrency = &opts
+	return f
+}
+
 // WithRateLimiter enables a RateLimiter for Clients.
 func (f factory) WithRateLimiter(enabled bool) factory {
 	f.rateLimiterEnabled = enabled
@@ -149,6 +170,85 @@ func (f factory) WithCustomHeaders(headers map[string]string) factory {
 	return f
 }
 
+// WithTracer sets the Tracer used by the underlying rest/http clients to trace requests and operations.
+func (f factory) WithTracer(tracer rest.Tracer) factory {
+	f.tracer = tracer
+	return f
+}
+
+// WithMetrics sets the MetricsCollector the underlying rest/http clients report request metrics to.
+func (f factory) WithMetrics(collector rest.MetricsCollector) factory {
+	f.metrics = collector
+	return f
+}
+
+// WithCircuitBreaker sets the CircuitBreaker protecting the requests of the underlying rest/http clients.
+// The CircuitBreaker is shared by all clients created by the factory, so that a broken host fails fast for all of them.
+func (f factory) WithCircuitBreaker(cb *rest.CircuitBreaker) factory {
+	f.circuitBreaker = cb
+	return f
+}
+
+// WithRecorder sets the Recorder used by the underlying rest/http clients to record their requests and responses to a
+// cassette, or to replay them from it.
+func (f factory) WithRecorder(recorder *rest.Recorder) factory {
+	f.recorder = recorder
+	return f
+}
+
+// WithCache sets the Cache used by the underlying rest/http clients to send GET requests as conditional requests and
+// to serve unmodified responses from. See rest.WithCache.
+func (f factory) WithCache(cache rest.Cache) factory {
+	f.cache = cache
+	return f
+}
+
+// WithRequestCoalescing enables collapsing identical GET requests which are in flight at the same time into a single
+// HTTP call. See rest.WithRequestCoalescing.
+func (f factory) WithRequestCoalescing() factory {
+	f.requestCoalescing = true
+	return f
+}
+
+// WithStreaming enables the streaming mode of the underlying rest/http clients, spooling large request and response
+// bodies to disk instead of holding them in memory. See rest.WithStreaming.
+func (f factory) WithStreaming(opts rest.StreamingOptions) factory {
+	f.streaming = &opts
+	return f
+}
+
+// WithIdempotency enables generating idempotency keys for POST requests of the underlying rest/http clients.
+// See rest.WithIdempotency.
+func (f factory) WithIdempotency(opts rest.IdempotencyOptions) factory {
+	f.idempotency = &opts
+	return f
+}
+
+// WithDryRun enables the dry-run mode of the underlying rest/http clients, which don't send mutating requests but record
+// them in a plan. All clients created by the factory share the same plan, which is created if opts.Plan is not set.
+// See rest.WithDryRun.
+func (f factory) WithDryRun(opts rest.DryRunOptions) factory {
+	if opts.Plan == nil {
+		opts.Plan = rest.NewDryRunPlan()
+	}
+	f.dryRun = &opts
+	return f
+}
+
+// WithLogger sets the logger of the underlying rest/http clients and the API clients using them. See rest.WithLogger.
+func (f factory) WithLogger(logger *slog.Logger) factory {
+	f.logger = logger
+	return f
+}
+
+// WithRedactor sets the redact.Redactor the redactors of the underlying rest clients are based on. Each rest client
+// gets a copy of it which additionally masks the token or client secret it authenticates with. If not set,
+// redact.Default() is used. See rest.WithRedactor.
+func (f factory) WithRedactor(redactor *redact.Redactor) factory {
+	f.redactor = redactor
+	return f
+}
+
 // AccountClient creates and returns a new instance of accounts.Client for interacting with the accounts API.
 func (f factory) AccountClient(ctx context.Context) (*accounts.Client, error) {
 	restClient, err := f.AccountRestClient(ctx)
@@ -168,7 +268,7 @@ func (f factory) AccountRestClient(ctx context.Context) (*rest.Client, error) {
 		return nil, ErrAccountURLMissing
 	}
 
-	return f.createRestClient(f.accountURL, auth.NewOAuthClient(ctx, f.oauthConfig))
+	return f.createRestClient(f.accountURL, auth.NewOAuthClient(ctx, f.oauthConfig), f.oauthConfig.ClientSecret)
 }
 
 // AutomationClient creates and returns a new instance of automation.Client for interacting with the automation API.
@@ -236,14 +336,10 @@ func (f factory) CreatePlatformClient(ctx context.Context) (*rest.Client, error)
 		return nil, ErrPlatformURLMissing
 	}
 
-	var client *http.Client
 	if f.platformToken != "" {
-		client = auth.NewPlatformTokenClient(ctx, f.platformToken)
-	} else {
-		client = auth.NewOAuthClient(ctx, f.oauthConfig)
+		return f.createRestClient(f.platformURL, auth.NewPlatformTokenClient(ctx, f.platformToken), f.platformToken)
 	}
-
-	return f.createRestClient(f.platformURL, client)
+	return f.createRestClient(f.platformURL, auth.NewOAuthClient(ctx, f.oauthConfig), f.oauthConfig.ClientSecret)
 }
 
 // CreateClassicClient creates a REST client configured for accessing classic APIs.
@@ -261,16 +357,22 @@ func (f factory) CreateClassicClientWithContext(ctx context.Context) (*rest.Clie
 		return nil, ErrClassicURLMissing
 	}
 
-	return f.createRestClient(f.classicURL, auth.NewAPITokenClient(ctx, f.accessToken))
+	return f.createRestClient(f.classicURL, auth.NewAPITokenClient(ctx, f.accessToken), f.accessToken)
 }
 
-func (f factory) createRestClient(u string, httpClient *http.Client) (*rest.Client, error) {
+// createRestClient creates a rest client for the given URL, whose Redactor masks the given secrets it authenticates
+// with.
+func (f factory) createRestClient(u string, httpClient *http.Client, secrets ...string) (*rest.Client, error) {
 	parsedURL, err := url.Parse(u)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse URL %q: %w", u, err)
 	}
 
-	opts := f.restOptions()
+	redactor := f.redactor
+	if redactor == nil {
+		redactor = redact.Default()
+	}
+	opts := append(f.restOptions(), rest.WithRedactor(redactor.With(redact.Options{Secrets: secrets})))
 
 	restClient := rest.NewClient(parsedURL, httpClient, opts...)
 	if f.userAgent != "" {
@@ -288,6 +390,10 @@ func (f factory) restOptions() []rest.Option {
 		rest.WithHTTPListener(f.httpListener),
 		rest.WithConcurrentRequestLimit(f.concurrentRequestLimit),
 	}
+	if f.adaptiveConcurrency != nil {
+		opts = append(opts, rest.WithAdaptiveConcurrency(*f.adaptiveConcurrency))
+	}
+
 	if f.rateLimiterEnabled {
 		opts = append(opts, rest.WithRateLimiter())
 	}
@@ -295,5 +401,45 @@ func (f factory) restOptions() []rest.Option {
 	if f.retryOptions != nil {
 		opts = append(opts, rest.WithRetryOptions(f.retryOptions))
 	}
+
+	if f.tracer != nil {
+		opts = append(opts, rest.WithTracer(f.tracer))
+	}
+
+	if f.metrics != nil {
+		opts = append(opts, rest.WithMetrics(f.metrics))
+	}
+
+	if f.circuitBreaker != nil {
+		opts = append(opts, rest.WithCircuitBreaker(f.circuitBreaker))
+	}
+
+	if f.recorder != nil {
+		opts = append(opts, rest.WithRecorder(f.recorder))
+	}
+
+	if f.cache != nil {
+		opts = append(opts, rest.WithCache(f.cache))
+	}
+
+	if f.requestCoalescing {
+		opts = append(opts, rest.WithRequestCoalescing())
+	}
+
+	if f.streaming != nil {
+		opts = append(opts, rest.WithStreaming(*f.streaming))
+	}
+
+	if f.idempotency != nil {
+		opts = append(opts, rest.WithIdempotency(*f.idempotency))
+	}
+
+	if f.dryRun != nil {
+		opts = append(opts, rest.WithDryRun(*f.dryRun))
+	}
+
+	if f.logger != nil {
+		opts = append(opts, rest.WithLogger(f.logger))
+	}
 	return opts
 }
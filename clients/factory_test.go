@@ -17,11 +17,16 @@ package clients
 import (
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2/clientcredentials"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/redact"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/testutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/accounts"
@@ -713,3 +718,48 @@ func TestCreatePlatformClient_NoTokenSet(t *testing.T) {
 	assert.ErrorIs(t, err, ErrNoPlatformCredentialsProvided)
 
 }
+
+func TestFactory_WithRecorder(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, os.WriteFile(cassette, []byte(`{"interactions":[{
+		"request":{"method":"GET","url":"https://example.com/platform/storage/management/v1/bucket-definitions/my-bucket"},
+		"response":{"statusCode":200,"body":"{\"bucketName\":\"my-bucket\"}"}
+	}]}`), 0o600))
+
+	recorder, err := rest.NewRecorder(rest.RecorderOptions{Mode: rest.ReplayMode, Path: cassette})
+	require.NoError(t, err)
+
+	client, err := Factory().
+		WithPlatformURL("https://example.com").
+		WithPlatformToken("token").
+		WithRecorder(recorder).
+		BucketClient(t.Context())
+	require.NoError(t, err)
+
+	resp, err := client.Get(t.Context(), "my-bucket")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"bucketName":"my-bucket"}`, string(resp.Data))
+}
+
+func TestFactory_SecretsAreRedactedPerClient(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message": "invalid value secret-platform-token"}`))
+	}))
+	defer apiServer.Close()
+
+	client, err := Factory().
+		WithPlatformURL(apiServer.URL).
+		WithPlatformToken("secret-platform-token").
+		CreatePlatformClient(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, "REDACTED", client.Redactor().String("secret-platform-token"))
+	assert.Equal(t, "secret-platform-token", redact.Default().String("secret-platform-token"), "secrets must not be added to the default redactor")
+
+	resp, err := client.GET(t.Context(), "", rest.RequestOptions{})
+	require.NoError(t, err)
+	_, err = api.NewResponseFromHTTPResponse(resp)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret-platform-token")
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 
@@ -30,6 +31,7 @@ import (
 const (
 	maxUpdateAttempts        = 10
 	openPipelineResourcePath = "/platform/openpipeline/v1/configurations"
+	openPipelineEndpoint     = openPipelineResourcePath + "/{id}"
 
 	errMsg       = "failed to %s openpipeline resource: %w"
 	errMsgWithId = "failed to %s openpipeline resource with id %s: %w"
@@ -39,7 +41,7 @@ const (
 	listOperation   = "list"
 )
 
-var ErrEmptyID = errors.New("id must be non-empty")
+var ErrEmptyID = api.NewValidationSentinel("id must be non-empty")
 
 type ListResponse struct {
 	Id       string `json:"id"`
@@ -59,7 +61,10 @@ type Client struct {
 	restClient *rest.Client
 }
 
-func (c Client) Get(ctx context.Context, id string) (api.Response, error) {
+func (c Client) Get(ctx context.Context, id string) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "openpipeline.Client.Get", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
 		return api.Response{}, fmt.Errorf(errMsg, getOperation, ErrEmptyID)
 	}
@@ -69,7 +74,7 @@ func (c Client) Get(ctx context.Context, id string) (api.Response, error) {
 		return api.Response{}, fmt.Errorf(errMsgWithId, getOperation, id, err)
 	}
 
-	resp, err := c.restClient.GET(ctx, path, rest.RequestOptions{})
+	resp, err := c.restClient.GET(ctx, path, rest.RequestOptions{Endpoint: openPipelineEndpoint})
 	if err != nil {
 		return api.Response{}, fmt.Errorf(errMsgWithId, getOperation, id, err)
 	}
@@ -78,24 +83,33 @@ func (c Client) Get(ctx context.Context, id string) (api.Response, error) {
 }
 
 func (c Client) List(ctx context.Context) ([]ListResponse, error) {
-	httpResp, err := c.restClient.GET(ctx, openPipelineResourcePath, rest.RequestOptions{})
-	if err != nil {
-		return nil, fmt.Errorf(errMsg, listOperation, err)
-	}
-	resp, err := api.NewResponseFromHTTPResponse(httpResp)
+	paginator := api.Paginator{
+		Client:    c.restClient,
+		Strategy:  api.SinglePagePagination{},
+		Path:      openPipelineResourcePath,
+		Resource:  "openpipeline",
+		WrapError: func(err error) error { return fmt.Errorf(errMsg, listOperation, err) },
+	}
+	pages, err := api.CollectPages(paginator.Pages(ctx, "openpipeline.Client.List"))
 	if err != nil {
-		return nil, fmt.Errorf(errMsg, listOperation, err)
+		return nil, err
 	}
 
 	var resources []ListResponse
-	err = json.Unmarshal(resp.Data, &resources)
-	if err != nil {
-		return nil, fmt.Errorf(errMsg, listOperation, err)
+	for _, o := range pages.All() {
+		var r ListResponse
+		if err := json.Unmarshal(o, &r); err != nil {
+			return nil, fmt.Errorf(errMsg, listOperation, err)
+		}
+		resources = append(resources, r)
 	}
 	return resources, nil
 }
 
-func (c Client) GetAll(ctx context.Context) ([]api.Response, error) {
+func (c Client) GetAll(ctx context.Context) (_ []api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "openpipeline.Client.GetAll")
+	defer func() { endOperation(err) }()
+
 	listResp, err := c.List(ctx)
 	if err != nil {
 		return nil, err
@@ -112,9 +126,11 @@ func (c Client) GetAll(ctx context.Context) ([]api.Response, error) {
 	return resources, nil
 }
 
-func (c Client) Update(ctx context.Context, id string, payload []byte) (api.Response, error) {
+func (c Client) Update(ctx context.Context, id string, payload []byte) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "openpipeline.Client.Update", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	var resp api.Response
-	var err error
 
 	for range maxUpdateAttempts {
 		resp, err = c.update(ctx, id, payload)
@@ -160,7 +176,7 @@ func (c Client) update(ctx context.Context, id string, payload []byte) (api.Resp
 		return api.Response{}, fmt.Errorf(errMsgWithId, updateOperation, id, err)
 	}
 
-	resp, err := c.restClient.PUT(ctx, path, bytes.NewReader(payload), rest.RequestOptions{})
+	resp, err := c.restClient.PUT(ctx, path, bytes.NewReader(payload), rest.RequestOptions{Endpoint: openPipelineEndpoint})
 	if err != nil {
 		return api.Response{}, fmt.Errorf(errMsgWithId, updateOperation, id, err)
 	}
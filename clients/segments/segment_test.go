@@ -74,6 +74,20 @@ func TestList(t *testing.T) {
 	require.JSONEq(t, expected, string(resp.Data))
 }
 
+func TestList_NoSegments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	url, _ := url.Parse(server.URL)
+	client := segments.NewClient(rest.NewClient(url, server.Client()))
+
+	resp, err := client.List(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(resp.Data))
+}
+
 func TestGet(t *testing.T) {
 	t.Run("when called without id parameter, returns a validation error", func(t *testing.T) {
 		client := segments.NewClient(&rest.Client{})
@@ -82,6 +96,7 @@ func TestGet(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, api.ValidationError{Resource: "segments", Field: "id", Reason: "is empty"})
+		assert.ErrorIs(t, err, api.ErrValidation)
 		assert.Empty(t, actual)
 	})
 
@@ -113,6 +128,7 @@ func TestGet(t *testing.T) {
 		var apiErr api.APIError
 		assert.ErrorAs(t, err, &apiErr)
 		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.ErrorIs(t, err, api.ErrNotFound)
 		assert.Equal(t, apiResponse, string(apiErr.Body))
 	})
 
@@ -685,6 +701,7 @@ func TestDelete(t *testing.T) {
 		var apiErr api.APIError
 		errors.As(err, &apiErr)
 		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.ErrorIs(t, err, api.ErrNotFound)
 		assert.Equal(t, apiResponse, string(apiErr.Body))
 	})
 
@@ -724,3 +741,118 @@ func assertRequestPayload(t *testing.T, r *http.Request, expectedUID string, exp
 	assert.Equal(t, testRequest.Owner, expectedOwner)
 	assert.Equal(t, testRequest.UID, expectedUID)
 }
+
+func TestListObjects(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.Equal(t, "/platform/storage/filter-segments/v1/filter-segments:lean", r.URL.Path)
+		require.Equal(t, "EXTERNALID", r.URL.Query().Get("add-fields"))
+		w.Write([]byte(`{"filterSegments": [{"uid": "a"}, {"uid": "b"}]}`))
+	}))
+	defer server.Close()
+
+	url, _ := url.Parse(server.URL)
+	client := segments.NewClient(rest.NewClient(url, server.Client()))
+
+	var uids []string
+	for o, err := range api.DecodeObjects[struct{ UID string }](client.ListObjects(t.Context())) {
+		require.NoError(t, err)
+		uids = append(uids, o.UID)
+	}
+	assert.Equal(t, []string{"a", "b"}, uids)
+
+	var pages int
+	for page, err := range client.ListPages(t.Context()) {
+		require.NoError(t, err)
+		assert.Len(t, page.Objects, 2)
+		pages++
+	}
+	assert.Equal(t, 1, pages)
+	assert.Equal(t, 2, requests)
+}
+
+func TestList_Errors(t *testing.T) {
+	t.Run("API errors are returned", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		url, _ := url.Parse(server.URL)
+		client := segments.NewClient(rest.NewClient(url, server.Client()))
+
+		resp, err := client.List(t.Context())
+		assert.Empty(t, resp)
+		assert.ErrorIs(t, err, api.ErrForbidden)
+		assert.ErrorAs(t, err, &api.ClientError{})
+	})
+
+	t.Run("failed requests result in client errors", func(t *testing.T) {
+		url, _ := url.Parse("http://localhost:0")
+		client := segments.NewClient(rest.NewClient(url, &http.Client{}))
+
+		resp, err := client.List(t.Context())
+		assert.Empty(t, resp)
+		var clientErr api.ClientError
+		require.ErrorAs(t, err, &clientErr)
+		assert.Equal(t, "segments", clientErr.Resource)
+		assert.Equal(t, http.MethodGet, clientErr.Operation)
+	})
+
+	t.Run("invalid payloads result in runtime errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"filterSegments": "invalid"}`))
+		}))
+		defer server.Close()
+
+		url, _ := url.Parse(server.URL)
+		client := segments.NewClient(rest.NewClient(url, server.Client()))
+
+		_, err := client.List(t.Context())
+		assert.ErrorAs(t, err, &api.RuntimeError{})
+	})
+}
+
+func TestOperationsAreTraced(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"uid": "uid"}`))
+	}))
+	defer server.Close()
+
+	tracer := rest.NewInMemoryTracer()
+	url, _ := url.Parse(server.URL)
+	client := segments.NewClient(rest.NewClient(url, server.Client(), rest.WithTracer(tracer)))
+
+	tests := []struct {
+		operation string
+		call      func() error
+	}{
+		{"segments.Client.Get", func() error { _, err := client.Get(t.Context(), "uid"); return err }},
+		{"segments.Client.Create", func() error { _, err := client.Create(t.Context(), []byte(`{}`)); return err }},
+		{"segments.Client.Delete", func() error { _, err := client.Delete(t.Context(), "uid"); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			tracer.Reset()
+			require.NoError(t, tt.call())
+
+			spans := tracer.Spans()
+			require.Len(t, spans, 2)
+			request, operation := spans[0], spans[1]
+			assert.Equal(t, tt.operation, operation.Name)
+			assert.Equal(t, operation.SpanContext.SpanID, request.ParentSpanID)
+		})
+	}
+
+	t.Run("errors are recorded", func(t *testing.T) {
+		tracer.Reset()
+		_, err := client.Delete(t.Context(), "")
+		require.Error(t, err)
+
+		spans := tracer.Spans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "segments.Client.Delete", spans[0].Name)
+		assert.NotEmpty(t, spans[0].Errors)
+	})
+}
@@ -20,6 +20,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -30,6 +32,7 @@ import (
 
 const endpointPath = "platform/storage/filter-segments/v1/filter-segments"
 const resource = "segments"
+const segmentEndpoint = "/" + endpointPath + "/{id}"
 
 var basePath = url.URL{Path: endpointPath}
 var idValidationErr = api.ValidationError{Resource: resource, Field: "id", Reason: "is empty"}
@@ -42,29 +45,22 @@ type Client struct {
 	restClient *rest.Client
 }
 
+// List returns all segments. The Data of the returned Response is a JSON list of the segments.
 func (c Client) List(ctx context.Context) (api.Response, error) {
-	path := basePath.String() + ":lean"
-	resp, err := c.restClient.GET(ctx, path, rest.RequestOptions{
-		QueryParams: url.Values{"add-fields": []string{"EXTERNALID"}},
-	})
-
-	if err != nil {
-		return api.Response{}, api.ClientError{Resource: resource, Operation: http.MethodGet, Wrapped: err}
-	}
-
-	apiResp, err := api.NewResponseFromHTTPResponse(resp)
+	pages, err := api.CollectPages(c.pages(ctx, "segments.Client.List"))
 	if err != nil {
-		return apiResp, err
+		return api.Response{}, err
 	}
 
-	if apiResp.Data, err = modifyBody(apiResp.Data); err != nil {
-		return apiResp, api.RuntimeError{Resource: resource, Reason: "body transformation failed", Wrapped: err}
+	// the segments API isn't paginated, so the single page contains all segments
+	resp := pages[0].Response
+	if resp.Data, err = modifyBody(resp.Data); err != nil {
+		return api.Response{}, api.RuntimeError{Resource: resource, Reason: "body transformation failed", Wrapped: err}
 	}
-
-	return apiResp, nil
+	return resp, nil
 }
 
-// modifyBody transform received json response to contain just a JSON list of elements
+// modifyBody returns the filterSegments field of the given payload, which is null if the field is missing.
 func modifyBody(source []byte) ([]byte, error) {
 	var transformed map[string]any
 	if err := json.Unmarshal(source, &transformed); err != nil {
@@ -77,13 +73,43 @@ func modifyBody(source []byte) ([]byte, error) {
 	return body, nil
 }
 
-func (c Client) Get(ctx context.Context, id string) (api.Response, error) {
+// ListPages returns an iterator over the pages of all segments. The segments API isn't paginated, so there is a single
+// page only.
+func (c Client) ListPages(ctx context.Context) iter.Seq2[api.ListResponse, error] {
+	return c.pages(ctx, "segments.Client.ListPages")
+}
+
+// ListObjects returns an iterator over all segments. Use api.DecodeObjects to iterate over typed objects.
+func (c Client) ListObjects(ctx context.Context) iter.Seq2[[]byte, error] {
+	return api.Objects(c.pages(ctx, "segments.Client.ListObjects"))
+}
+
+func (c Client) pages(ctx context.Context, operation string) iter.Seq2[api.ListResponse, error] {
+	paginator := api.Paginator{
+		Client:       c.restClient,
+		Strategy:     api.SinglePagePagination{},
+		Path:         basePath.String() + ":lean",
+		Options:      rest.RequestOptions{QueryParams: url.Values{"add-fields": []string{"EXTERNALID"}}},
+		ObjectsField: "filterSegments",
+		Resource:     resource,
+		WrapError: func(err error) error {
+			return api.ClientError{Resource: resource, Operation: http.MethodGet, Wrapped: err}
+		},
+	}
+	return paginator.Pages(ctx, operation)
+}
+
+func (c Client) Get(ctx context.Context, id string) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "segments.Client.Get", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
 		return api.Response{}, idValidationErr
 	}
 
 	path := basePath.JoinPath(id).String()
 	resp, err := c.restClient.GET(ctx, path, rest.RequestOptions{
+		Endpoint:    segmentEndpoint,
 		QueryParams: url.Values{"add-fields": []string{"INCLUDES", "VARIABLES", "EXTERNALID", "RESOURCECONTEXT"}},
 	})
 	if err != nil {
@@ -93,7 +119,10 @@ func (c Client) Get(ctx context.Context, id string) (api.Response, error) {
 	return api.NewResponseFromHTTPResponse(resp)
 }
 
-func (c Client) Create(ctx context.Context, body []byte) (api.Response, error) {
+func (c Client) Create(ctx context.Context, body []byte) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "segments.Client.Create")
+	defer func() { endOperation(err) }()
+
 	resp, err := c.restClient.POST(ctx, endpointPath, bytes.NewReader(body), rest.RequestOptions{})
 	if err != nil {
 		return api.Response{}, api.ClientError{Resource: resource, Operation: http.MethodPost, Wrapped: err}
@@ -102,7 +131,10 @@ func (c Client) Create(ctx context.Context, body []byte) (api.Response, error) {
 	return api.NewResponseFromHTTPResponse(resp)
 }
 
-func (c Client) Update(ctx context.Context, id string, body []byte) (api.Response, error) {
+func (c Client) Update(ctx context.Context, id string, body []byte) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "segments.Client.Update", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
 		return api.Response{}, idValidationErr
 	}
@@ -134,6 +166,7 @@ func (c Client) Update(ctx context.Context, id string, body []byte) (api.Respons
 
 	path := basePath.JoinPath(id).String()
 	resp, err := c.restClient.PUT(ctx, path, bytes.NewReader(body), rest.RequestOptions{
+		Endpoint:    segmentEndpoint,
 		QueryParams: map[string][]string{"optimistic-locking-version": {strconv.Itoa(getResponse.Version)}}})
 	if err != nil {
 		return api.Response{}, api.ClientError{Resource: resource, Operation: http.MethodPut, Identifier: id, Wrapped: err}
@@ -142,13 +175,16 @@ func (c Client) Update(ctx context.Context, id string, body []byte) (api.Respons
 	return api.NewResponseFromHTTPResponse(resp)
 }
 
-func (c Client) Delete(ctx context.Context, id string) (api.Response, error) {
+func (c Client) Delete(ctx context.Context, id string) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "segments.Client.Delete", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
 		return api.Response{}, idValidationErr
 	}
 
 	path := basePath.JoinPath(id).String()
-	resp, err := c.restClient.DELETE(ctx, path, rest.RequestOptions{})
+	resp, err := c.restClient.DELETE(ctx, path, rest.RequestOptions{Endpoint: segmentEndpoint})
 	if err != nil {
 		return api.Response{}, api.ClientError{Resource: resource, Operation: http.MethodDelete, Identifier: id, Wrapped: err}
 	}
@@ -156,7 +192,10 @@ func (c Client) Delete(ctx context.Context, id string) (api.Response, error) {
 	return api.NewResponseFromHTTPResponse(resp)
 }
 
-func (c Client) GetAll(ctx context.Context) ([]api.Response, error) {
+func (c Client) GetAll(ctx context.Context) (_ []api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "segments.Client.GetAll")
+	defer func() { endOperation(err) }()
+
 	listResp, err := c.List(ctx)
 	if err != nil {
 		return nil, err
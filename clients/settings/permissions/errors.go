@@ -15,14 +15,15 @@
 package permissions
 
 import (
-	"errors"
 	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
 )
 
 var (
-	ErrorMissingObjectID     = errors.New("objectID cannot be empty")
-	ErrorMissingAccessorID   = errors.New("accessorID cannot be empty")
-	ErrorMissingAccessorType = errors.New("accessorType cannot be empty")
+	ErrorMissingObjectID     = api.NewValidationSentinel("objectID cannot be empty")
+	ErrorMissingAccessorID   = api.NewValidationSentinel("accessorID cannot be empty")
+	ErrorMissingAccessorType = api.NewValidationSentinel("accessorType cannot be empty")
 )
 
 type ClientOperation string
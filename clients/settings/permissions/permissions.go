@@ -17,6 +17,7 @@ package permissions
 import (
 	"bytes"
 	"context"
+	"log/slog"
 	"net/url"
 	"strconv"
 
@@ -28,6 +29,9 @@ const endpointConfigPath = "platform/classic/environment-api/v2/settings/objects
 const permissionResourcePath = "permissions"
 const allUsersAccessorType = "all-users"
 
+const objectPermissionsEndpoint = "/" + endpointConfigPath + "/{objectId}/" + permissionResourcePath
+const accessorPermissionEndpoint = objectPermissionsEndpoint + "/{accessorType}/{accessorId}"
+
 type Client struct {
 	client *rest.Client
 }
@@ -36,15 +40,24 @@ func NewClient(client *rest.Client) *Client {
 	return &Client{client: client}
 }
 
-func (c *Client) GetAllAccessors(ctx context.Context, objectID string, adminAccess bool) (api.Response, error) {
+func (c *Client) GetAllAccessors(ctx context.Context, objectID string, adminAccess bool) (_ api.Response, err error) {
+	ctx, endOperation := c.client.StartOperation(ctx, "permissions.Client.GetAllAccessors", slog.String("objectId", objectID))
+	defer func() { endOperation(err) }()
+
 	return c.get(ctx, objectID, "", "", adminAccess)
 }
 
-func (c *Client) GetAllUsersAccessor(ctx context.Context, objectID string, adminAccess bool) (api.Response, error) {
+func (c *Client) GetAllUsersAccessor(ctx context.Context, objectID string, adminAccess bool) (_ api.Response, err error) {
+	ctx, endOperation := c.client.StartOperation(ctx, "permissions.Client.GetAllUsersAccessor", slog.String("objectId", objectID))
+	defer func() { endOperation(err) }()
+
 	return c.get(ctx, objectID, allUsersAccessorType, "", adminAccess)
 }
 
-func (c *Client) GetAccessor(ctx context.Context, objectID string, accessorType string, accessorID string, adminAccess bool) (api.Response, error) {
+func (c *Client) GetAccessor(ctx context.Context, objectID string, accessorType string, accessorID string, adminAccess bool) (_ api.Response, err error) {
+	ctx, endOperation := c.client.StartOperation(ctx, "permissions.Client.GetAccessor", slog.String("objectId", objectID), slog.String("accessorType", accessorType), slog.String("accessorId", accessorID))
+	defer func() { endOperation(err) }()
+
 	if accessorType == "" {
 		return api.Response{}, ErrorPermissions{Wrapped: ErrorMissingAccessorType, Operation: GET}
 	}
@@ -66,7 +79,7 @@ func (c *Client) get(ctx context.Context, objectID string, accessorType string,
 		return api.Response{}, ErrorPermissions{Wrapped: err, Operation: GET}
 	}
 
-	resp, err := c.client.GET(ctx, path, getRequestOptions(adminAccess))
+	resp, err := c.client.GET(ctx, path, getRequestOptions(adminAccess, accessorPermissionEndpoint))
 
 	if err != nil {
 		return api.Response{}, ErrorPermissions{Wrapped: err, Operation: GET}
@@ -75,7 +88,10 @@ func (c *Client) get(ctx context.Context, objectID string, accessorType string,
 	return api.NewResponseFromHTTPResponse(resp)
 }
 
-func (c *Client) Create(ctx context.Context, objectID string, adminAccess bool, body []byte) (api.Response, error) {
+func (c *Client) Create(ctx context.Context, objectID string, adminAccess bool, body []byte) (_ api.Response, err error) {
+	ctx, endOperation := c.client.StartOperation(ctx, "permissions.Client.Create", slog.String("objectId", objectID))
+	defer func() { endOperation(err) }()
+
 	if objectID == "" {
 		return api.Response{}, ErrorPermissions{Wrapped: ErrorMissingObjectID, Operation: POST}
 	}
@@ -85,7 +101,7 @@ func (c *Client) Create(ctx context.Context, objectID string, adminAccess bool,
 		return api.Response{}, ErrorPermissions{Wrapped: err, Operation: POST}
 	}
 
-	resp, err := c.client.POST(ctx, path, bytes.NewReader(body), getRequestOptions(adminAccess))
+	resp, err := c.client.POST(ctx, path, bytes.NewReader(body), getRequestOptions(adminAccess, objectPermissionsEndpoint))
 	if err != nil {
 		return api.Response{}, ErrorPermissions{Wrapped: err, Operation: POST}
 	}
@@ -93,11 +109,17 @@ func (c *Client) Create(ctx context.Context, objectID string, adminAccess bool,
 	return api.NewResponseFromHTTPResponse(resp)
 }
 
-func (c *Client) UpdateAllUsersAccessor(ctx context.Context, objectID string, adminAccess bool, body []byte) (api.Response, error) {
+func (c *Client) UpdateAllUsersAccessor(ctx context.Context, objectID string, adminAccess bool, body []byte) (_ api.Response, err error) {
+	ctx, endOperation := c.client.StartOperation(ctx, "permissions.Client.UpdateAllUsersAccessor", slog.String("objectId", objectID))
+	defer func() { endOperation(err) }()
+
 	return c.update(ctx, objectID, allUsersAccessorType, "", adminAccess, body)
 }
 
-func (c *Client) UpdateAccessor(ctx context.Context, objectID string, accessorType string, accessorID string, adminAccess bool, body []byte) (api.Response, error) {
+func (c *Client) UpdateAccessor(ctx context.Context, objectID string, accessorType string, accessorID string, adminAccess bool, body []byte) (_ api.Response, err error) {
+	ctx, endOperation := c.client.StartOperation(ctx, "permissions.Client.UpdateAccessor", slog.String("objectId", objectID), slog.String("accessorType", accessorType), slog.String("accessorId", accessorID))
+	defer func() { endOperation(err) }()
+
 	if accessorType == "" {
 		return api.Response{}, ErrorPermissions{Wrapped: ErrorMissingAccessorType, Operation: PUT}
 	}
@@ -119,7 +141,7 @@ func (c *Client) update(ctx context.Context, objectID string, accessorType strin
 		return api.Response{}, ErrorPermissions{Wrapped: err, Operation: PUT}
 	}
 
-	httpResp, err := c.client.PUT(ctx, path, bytes.NewReader(body), getRequestOptions(adminAccess))
+	httpResp, err := c.client.PUT(ctx, path, bytes.NewReader(body), getRequestOptions(adminAccess, accessorPermissionEndpoint))
 
 	if err != nil {
 		return api.Response{}, ErrorPermissions{Wrapped: err, Operation: PUT}
@@ -128,11 +150,17 @@ func (c *Client) update(ctx context.Context, objectID string, accessorType strin
 	return api.NewResponseFromHTTPResponse(httpResp)
 }
 
-func (c *Client) DeleteAllUsersAccessor(ctx context.Context, objectID string, adminAccess bool) (api.Response, error) {
+func (c *Client) DeleteAllUsersAccessor(ctx context.Context, objectID string, adminAccess bool) (_ api.Response, err error) {
+	ctx, endOperation := c.client.StartOperation(ctx, "permissions.Client.DeleteAllUsersAccessor", slog.String("objectId", objectID))
+	defer func() { endOperation(err) }()
+
 	return c.delete(ctx, objectID, allUsersAccessorType, "", adminAccess)
 }
 
-func (c *Client) DeleteAccessor(ctx context.Context, objectID string, accessorType string, accessorID string, adminAccess bool) (api.Response, error) {
+func (c *Client) DeleteAccessor(ctx context.Context, objectID string, accessorType string, accessorID string, adminAccess bool) (_ api.Response, err error) {
+	ctx, endOperation := c.client.StartOperation(ctx, "permissions.Client.DeleteAccessor", slog.String("objectId", objectID), slog.String("accessorType", accessorType), slog.String("accessorId", accessorID))
+	defer func() { endOperation(err) }()
+
 	if accessorType == "" {
 		return api.Response{}, ErrorPermissions{Wrapped: ErrorMissingAccessorType, Operation: DELETE}
 	}
@@ -154,7 +182,7 @@ func (c *Client) delete(ctx context.Context, objectID string, accessorType strin
 		return api.Response{}, ErrorPermissions{Wrapped: err, Operation: DELETE}
 	}
 
-	httpResp, err := c.client.DELETE(ctx, path, getRequestOptions(adminAccess))
+	httpResp, err := c.client.DELETE(ctx, path, getRequestOptions(adminAccess, accessorPermissionEndpoint))
 
 	if err != nil {
 		return api.Response{}, ErrorPermissions{Wrapped: err, Operation: DELETE}
@@ -163,8 +191,9 @@ func (c *Client) delete(ctx context.Context, objectID string, accessorType strin
 	return api.NewResponseFromHTTPResponse(httpResp)
 }
 
-func getRequestOptions(adminAccess bool) rest.RequestOptions {
+func getRequestOptions(adminAccess bool, endpoint string) rest.RequestOptions {
 	return rest.RequestOptions{
 		QueryParams: url.Values{"adminAccess": []string{strconv.FormatBool(adminAccess)}},
+		Endpoint:    endpoint,
 	}
 }
@@ -933,3 +933,23 @@ func TestClient_DeleteAllUsersAccessor(t *testing.T) {
 		assert.Equal(t, permissions.DELETE, errorPermissions.Operation)
 	})
 }
+
+func TestClient_OperationsAreTraced(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	tracer := rest.NewInMemoryTracer()
+	serverURL, _ := url.Parse(server.URL)
+	client := permissions.NewClient(rest.NewClient(serverURL, server.Client(), rest.WithTracer(tracer)))
+
+	_, err := client.GetAccessor(t.Context(), "objectID", "user", "userID", false)
+	require.NoError(t, err)
+
+	spans := tracer.Spans()
+	require.Len(t, spans, 2)
+	request, operation := spans[0], spans[1]
+	assert.Equal(t, "permissions.Client.GetAccessor", operation.Name)
+	assert.Equal(t, operation.SpanContext.SpanID, request.ParentSpanID)
+}
@@ -18,17 +18,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"iter"
+	"log/slog"
 	"net/url"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 )
 
-const endpointPath = "platform/slo/v1/slos"
+const (
+	endpointPath = "platform/slo/v1/slos"
+	sloEndpoint  = "/" + endpointPath + "/{id}"
+)
 
 func NewClient(client *rest.Client) *Client {
 	c := &Client{
@@ -37,47 +39,48 @@ func NewClient(client *rest.Client) *Client {
 	return c
 }
 
+// ErrEmptyID is returned if an operation is called with an empty id. It matches api.ErrValidation.
+var ErrEmptyID = api.NewValidationSentinel(`argument "id" is empty`)
+
 type Client struct {
 	restClient *rest.Client
 }
 
+// List returns all SLOs, fetching all pages before returning. See ListPages to fetch pages lazily.
 func (c *Client) List(ctx context.Context) (api.PagedListResponse, error) {
-	var retVal api.PagedListResponse
-
-	for hasNextPage, nextPageKey := true, ""; hasNextPage; {
-		var listResponse api.ListResponse
-		var err error
-
-		nextPageKey, listResponse, err = listPage(ctx, c, nextPageKey)
-		if err != nil {
-			return nil, fmt.Errorf(errMsg, "list", err)
-		}
-
-		retVal = append(retVal, listResponse)
-		hasNextPage = nextPageKey != ""
-	}
-
-	return retVal, nil
+	return api.CollectPages(c.pages(ctx, "slo.Client.List"))
+}
 
+// ListPages returns an iterator over the pages of all SLOs. Pages are fetched lazily while iterating, and no further
+// pages are fetched once the iteration is stopped.
+func (c *Client) ListPages(ctx context.Context) iter.Seq2[api.ListResponse, error] {
+	return c.pages(ctx, "slo.Client.ListPages")
 }
 
-func listPage(ctx context.Context, c *Client, pageKey string) (string, api.ListResponse, error) {
-	ro := rest.RequestOptions{}
-	if pageKey != "" {
-		ro.QueryParams = url.Values{"page-key": {pageKey}}
-	}
+// ListObjects returns an iterator over all SLOs, fetching pages lazily like ListPages. Use api.DecodeObjects to
+// iterate over typed objects.
+func (c *Client) ListObjects(ctx context.Context) iter.Seq2[[]byte, error] {
+	return api.Objects(c.pages(ctx, "slo.Client.ListObjects"))
+}
 
-	resp, err := c.restClient.GET(ctx, endpointPath, ro)
-	if err != nil {
-		return "", api.ListResponse{}, fmt.Errorf(errMsg, "list", err)
+func (c *Client) pages(ctx context.Context, operation string) iter.Seq2[api.ListResponse, error] {
+	paginator := api.Paginator{
+		Client:       c.restClient,
+		Strategy:     api.PageKeyStrategy(),
+		Path:         endpointPath,
+		ObjectsField: "slos",
+		Resource:     "slo",
+		WrapError:    func(err error) error { return fmt.Errorf(errMsg, "list", err) },
 	}
-
-	return processListResponse(resp)
+	return paginator.Pages(ctx, operation)
 }
 
-func (c *Client) Get(ctx context.Context, id string) (api.Response, error) {
+func (c *Client) Get(ctx context.Context, id string) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "slo.Client.Get", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
-		return api.Response{}, fmt.Errorf(errMsgWithId, "get", id, errors.New(`argument "id" is empty`))
+		return api.Response{}, fmt.Errorf(errMsgWithId, "get", id, ErrEmptyID)
 	}
 
 	path, err := url.JoinPath(endpointPath, id)
@@ -85,7 +88,7 @@ func (c *Client) Get(ctx context.Context, id string) (api.Response, error) {
 		return api.Response{}, fmt.Errorf(errMsgWithId, "get", id, err)
 	}
 
-	resp, err := c.restClient.GET(ctx, path, rest.RequestOptions{})
+	resp, err := c.restClient.GET(ctx, path, rest.RequestOptions{Endpoint: sloEndpoint})
 	if err != nil {
 		return api.Response{}, fmt.Errorf(errMsgWithId, "get", id, err)
 	}
@@ -93,7 +96,10 @@ func (c *Client) Get(ctx context.Context, id string) (api.Response, error) {
 	return api.NewResponseFromHTTPResponse(resp)
 }
 
-func (c *Client) Create(ctx context.Context, body []byte) (api.Response, error) {
+func (c *Client) Create(ctx context.Context, body []byte) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "slo.Client.Create")
+	defer func() { endOperation(err) }()
+
 	resp, err := c.restClient.POST(ctx, endpointPath, bytes.NewReader(body), rest.RequestOptions{})
 	if err != nil {
 		return api.Response{}, fmt.Errorf(errMsg, "create", err)
@@ -102,9 +108,12 @@ func (c *Client) Create(ctx context.Context, body []byte) (api.Response, error)
 	return api.NewResponseFromHTTPResponse(resp)
 }
 
-func (c *Client) Update(ctx context.Context, id string, body []byte) (api.Response, error) {
+func (c *Client) Update(ctx context.Context, id string, body []byte) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "slo.Client.Update", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
-		return api.Response{}, fmt.Errorf(errMsgWithId, "update", id, errors.New(`argument "id" is empty`))
+		return api.Response{}, fmt.Errorf(errMsgWithId, "update", id, ErrEmptyID)
 	}
 
 	getResp, err := c.Get(ctx, id)
@@ -123,6 +132,7 @@ func (c *Client) Update(ctx context.Context, id string, body []byte) (api.Respon
 	}
 
 	resp, err := c.restClient.PUT(ctx, path, bytes.NewReader(body), rest.RequestOptions{
+		Endpoint:    sloEndpoint,
 		QueryParams: url.Values{"optimistic-locking-version": []string{version}},
 	})
 	if err != nil {
@@ -132,9 +142,12 @@ func (c *Client) Update(ctx context.Context, id string, body []byte) (api.Respon
 	return api.NewResponseFromHTTPResponse(resp)
 }
 
-func (c *Client) Delete(ctx context.Context, id string) (api.Response, error) {
+func (c *Client) Delete(ctx context.Context, id string) (_ api.Response, err error) {
+	ctx, endOperation := c.restClient.StartOperation(ctx, "slo.Client.Delete", slog.String("id", id))
+	defer func() { endOperation(err) }()
+
 	if id == "" {
-		return api.Response{}, fmt.Errorf(errMsgWithId, "delete", id, errors.New(`argument "id" is empty`))
+		return api.Response{}, fmt.Errorf(errMsgWithId, "delete", id, ErrEmptyID)
 	}
 
 	getResp, err := c.Get(ctx, id)
@@ -153,6 +166,7 @@ func (c *Client) Delete(ctx context.Context, id string) (api.Response, error) {
 	}
 
 	resp, err := c.restClient.DELETE(ctx, path, rest.RequestOptions{
+		Endpoint:    sloEndpoint,
 		QueryParams: url.Values{"optimistic-locking-version": []string{version}},
 	})
 	if err != nil {
@@ -177,42 +191,3 @@ func getOptimisticLockingVersion(resp api.Response) (string, error) {
 
 	return body.Version, nil
 }
-
-func processListResponse(httpResponse *http.Response) (nextPageKey string, resp api.ListResponse, err error) {
-	defer httpResponse.Body.Close()
-
-	var body json.RawMessage
-	if body, err = io.ReadAll(httpResponse.Body); err != nil {
-		return "", api.ListResponse{}, api.NewAPIErrorFromResponse(httpResponse)
-	}
-
-	if !rest.IsSuccess(httpResponse) {
-		return "", api.ListResponse{}, api.NewAPIErrorFromResponseAndBody(httpResponse, body)
-	}
-
-	var s struct {
-		NextPage string            `json:"nextPageKey"`
-		Data     []json.RawMessage `json:"slos"`
-	}
-
-	if err := json.Unmarshal(body, &s); err != nil {
-		return "", api.ListResponse{}, api.NewAPIErrorFromResponseAndBody(httpResponse, body)
-	}
-
-	var data [][]byte
-	for _, it := range s.Data {
-		data = append(data, it)
-	}
-
-	resp = api.ListResponse{
-		Response: api.Response{
-			StatusCode: httpResponse.StatusCode,
-			Header:     httpResponse.Header,
-			Data:       nil,
-			Request:    api.NewRequestInfoFromRequest(httpResponse.Request),
-		},
-		Objects: data,
-	}
-
-	return s.NextPage, resp, nil
-}
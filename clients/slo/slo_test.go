@@ -159,13 +159,65 @@ func TestList(t *testing.T) {
 	})
 }
 
+func TestListObjects(t *testing.T) {
+	var pageKeys []string
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		pageKey := request.URL.Query().Get("page-key")
+		pageKeys = append(pageKeys, pageKey)
+		switch pageKey {
+		case "":
+			writer.Write([]byte(`{"nextPageKey": "page-2", "slos": [{"id": "slo-id-1"}, {"id": "slo-id-2"}]}`))
+		case "page-2":
+			writer.Write([]byte(`{"slos": [{"id": "slo-id-3"}]}`))
+		default:
+			require.Failf(t, "unexpected call", "unexpected call with page-key= %s", pageKey)
+		}
+	}))
+	defer server.Close()
+
+	url, _ := url.Parse(server.URL)
+	client := slo.NewClient(rest.NewClient(url, server.Client()))
+
+	t.Run("fetches pages lazily", func(t *testing.T) {
+		pageKeys = nil
+
+		var ids []string
+		for o, err := range api.DecodeObjects[struct{ ID string }](client.ListObjects(t.Context())) {
+			require.NoError(t, err)
+			ids = append(ids, o.ID)
+			if o.ID == "slo-id-2" {
+				break
+			}
+		}
+
+		assert.Equal(t, []string{"slo-id-1", "slo-id-2"}, ids)
+		assert.Equal(t, []string{""}, pageKeys, "the second page must not be fetched")
+	})
+
+	t.Run("pages expose the response of each page", func(t *testing.T) {
+		pageKeys = nil
+
+		var objects int
+		for page, err := range client.ListPages(t.Context()) {
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, page.StatusCode)
+			assert.Contains(t, page.Request.URL, "/platform/slo/v1/slos")
+			objects += len(page.Objects)
+		}
+
+		assert.Equal(t, 3, objects)
+		assert.Equal(t, []string{"", "page-2"}, pageKeys)
+	})
+}
+
 func TestGet(t *testing.T) {
 	t.Run("when called without id parameter, returns an error", func(t *testing.T) {
 		client := slo.NewClient(&rest.Client{})
 
 		actual, err := client.Get(t.Context(), "")
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, slo.ErrEmptyID)
+		assert.ErrorIs(t, err, api.ErrValidation)
 		assert.Empty(t, actual)
 	})
 
@@ -236,6 +288,7 @@ func TestGet(t *testing.T) {
 		var apiErr api.APIError
 		assert.ErrorAs(t, err, &apiErr)
 		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.ErrorIs(t, err, api.ErrNotFound)
 		assert.Equal(t, errorResponse, string(apiErr.Body))
 	})
 }
@@ -284,7 +337,8 @@ func TestUpdate(t *testing.T) {
 
 		actual, err := client.Update(t.Context(), "", nil)
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, slo.ErrEmptyID)
+		assert.ErrorIs(t, err, api.ErrValidation)
 		assert.Empty(t, actual)
 	})
 
@@ -375,6 +429,7 @@ func TestUpdate(t *testing.T) {
 		var apiErr api.APIError
 		assert.ErrorAs(t, err, &apiErr)
 		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.ErrorIs(t, err, api.ErrNotFound)
 		assert.Equal(t, get404Response, string(apiErr.Body))
 	})
 }
@@ -385,7 +440,8 @@ func TestDelete(t *testing.T) {
 
 		actual, err := client.Delete(t.Context(), "")
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, slo.ErrEmptyID)
+		assert.ErrorIs(t, err, api.ErrValidation)
 		assert.Empty(t, actual)
 	})
 
@@ -459,6 +515,7 @@ func TestDelete(t *testing.T) {
 		var apiErr api.APIError
 		errors.As(err, &apiErr)
 		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.ErrorIs(t, err, api.ErrNotFound)
 		assert.Equal(t, get404Response, string(apiErr.Body))
 	})
 }